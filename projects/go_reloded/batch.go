@@ -0,0 +1,128 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+
+	"go_reloded/reloaded"
+)
+
+// batchFile is one file of a batch and how transforming it went.
+type batchFile struct {
+	rel string // path relative to the input directory
+	out bytes.Buffer
+	err error
+}
+
+// runBatch transforms every regular file under inDir into the same path
+// under outDir, mirroring the directories, with at most workers files in
+// flight. A file that fails is reported and the rest of the batch still
+// runs. What every file reported and a summary are written to w;
+// runBatch returns how many files failed, and an error only when the
+// batch could not start.
+func runBatch(inDir, outDir string, workers int, run runSettings, w io.Writer) (int, error) {
+	if info, err := os.Stat(inDir); err != nil {
+		return 0, err
+	} else if !info.IsDir() {
+		return 0, fmt.Errorf("%s is not a directory", inDir)
+	}
+	if within(outDir, inDir) {
+		return 0, fmt.Errorf("output directory %s is inside input directory %s", outDir, inDir)
+	}
+	workers = max(workers, 1)
+
+	paths := make(chan string)
+	files := make(chan *batchFile)
+	var wg sync.WaitGroup
+	for range workers {
+		wg.Go(func() {
+			for rel := range paths {
+				files <- transformFile(inDir, outDir, rel, run)
+			}
+		})
+	}
+	go func() {
+		walkErr := filepath.WalkDir(inDir, func(path string, d fs.DirEntry, err error) error {
+			rel, _ := filepath.Rel(inDir, path)
+			if err != nil {
+				files <- &batchFile{rel: rel, err: err}
+				return nil
+			}
+			switch {
+			case d.IsDir():
+				if err := os.MkdirAll(filepath.Join(outDir, rel), 0755); err != nil {
+					files <- &batchFile{rel: rel, err: err}
+					return fs.SkipDir
+				}
+			case d.Type().IsRegular():
+				paths <- rel
+			}
+			return nil
+		})
+		if walkErr != nil {
+			files <- &batchFile{rel: ".", err: walkErr}
+		}
+		close(paths)
+		wg.Wait()
+		close(files)
+	}()
+
+	var done []*batchFile
+	for f := range files {
+		done = append(done, f)
+	}
+	slices.SortFunc(done, func(a, b *batchFile) int { return strings.Compare(a.rel, b.rel) })
+
+	failed := 0
+	for _, f := range done {
+		w.Write(f.out.Bytes())
+		if f.err != nil {
+			failed++
+			fmt.Fprintf(w, "%s: Error: %v\n", f.rel, f.err)
+		}
+	}
+	fmt.Fprintf(w, "%d file(s) transformed, %d failed\n", len(done)-failed, failed)
+	return failed, nil
+}
+
+// transformFile transforms inDir/rel into outDir/rel, creating the
+// directories it needs.
+func transformFile(inDir, outDir, rel string, run runSettings) *batchFile {
+	f := &batchFile{rel: rel}
+	in, err := os.Open(filepath.Join(inDir, rel))
+	if err != nil {
+		f.err = err
+		return f
+	}
+	defer in.Close()
+
+	out := filepath.Join(outDir, rel)
+	if err := os.MkdirAll(filepath.Dir(out), 0755); err != nil {
+		f.err = err
+		return f
+	}
+	opts, rep := run.options()
+	f.err = writeOutput(out, func(w io.Writer) error {
+		return reloaded.Process(in, w, opts)
+	})
+	rep.print(&f.out, rel+": ")
+	return f
+}
+
+// within reports whether path is dir or inside it.
+func within(path, dir string) bool {
+	absPath, err1 := filepath.Abs(path)
+	absDir, err2 := filepath.Abs(dir)
+	if err1 != nil || err2 != nil {
+		return false
+	}
+	rel, err := filepath.Rel(absDir, absPath)
+	return err == nil && rel != ".." && !strings.HasPrefix(rel, ".."+string(filepath.Separator))
+}
@@ -0,0 +1,51 @@
+package main
+
+import (
+	"bufio"
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+)
+
+// applyConfig reads settings from the named file into the flags of fs.
+// Each line is "name = value" where name is a flag, and "#" starts a
+// comment:
+//
+//	# only tidy punctuation and quotes
+//	passes = punctuation, quotes
+//	strict = true
+//
+// Flags given on the command line win over the file.
+func applyConfig(fs *flag.FlagSet, name string) error {
+	f, err := os.Open(name)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	set := map[string]bool{}
+	fs.Visit(func(f *flag.Flag) { set[f.Name] = true })
+
+	sc := bufio.NewScanner(f)
+	for line := 1; sc.Scan(); line++ {
+		text, _, _ := strings.Cut(sc.Text(), "#")
+		if strings.TrimSpace(text) == "" {
+			continue
+		}
+		key, value, ok := strings.Cut(text, "=")
+		key, value = strings.TrimSpace(key), strings.TrimSpace(value)
+		switch {
+		case !ok:
+			return fmt.Errorf("%s:%d: expected name = value", name, line)
+		case key == "config" || fs.Lookup(key) == nil:
+			return fmt.Errorf("%s:%d: unknown setting %q", name, line, key)
+		case set[key]:
+			continue
+		}
+		if err := fs.Set(key, value); err != nil {
+			return fmt.Errorf("%s:%d: %v", name, line, err)
+		}
+	}
+	return sc.Err()
+}
@@ -0,0 +1,232 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+)
+
+const diffContext = 3
+
+// edit is one line of an edit script: op is ' ' for a line both sides
+// share, '-' for a line only in the old text and '+' for one only in the
+// new text.
+type edit struct {
+	op   byte
+	text string
+}
+
+// unifiedDiff returns the differences between the lines of a and b in
+// unified format, or "" when they are equal.
+func unifiedDiff(aName, bName, a, b string) string {
+	if a == b {
+		return ""
+	}
+	edits := diffLines(splitLines(a), splitLines(b))
+
+	// aPos[i] and bPos[i] count the lines of a and b before edits[i].
+	aPos := make([]int, len(edits)+1)
+	bPos := make([]int, len(edits)+1)
+	for i, e := range edits {
+		aPos[i+1], bPos[i+1] = aPos[i], bPos[i]
+		if e.op != '+' {
+			aPos[i+1]++
+		}
+		if e.op != '-' {
+			bPos[i+1]++
+		}
+	}
+
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "--- %s\n+++ %s\n", aName, bName)
+	for i := 0; i < len(edits); {
+		if edits[i].op == ' ' {
+			i++
+			continue
+		}
+		start := max(i-diffContext, 0)
+		end := i
+		for j := i; j < len(edits); j++ {
+			if edits[j].op != ' ' {
+				end = j + 1
+			} else if j-end >= 2*diffContext {
+				break
+			}
+		}
+		end = min(end+diffContext, len(edits))
+
+		fmt.Fprintf(&sb, "@@ -%s +%s @@\n", hunkRange(aPos[start], aPos[end]), hunkRange(bPos[start], bPos[end]))
+		for _, e := range edits[start:end] {
+			sb.WriteByte(e.op)
+			sb.WriteString(e.text)
+			if !strings.HasSuffix(e.text, "\n") {
+				sb.WriteString("\n\\ No newline at end of file\n")
+			}
+		}
+		i = end
+	}
+	return sb.String()
+}
+
+// hunkRange formats the lines from..to of one side of a hunk.
+func hunkRange(from, to int) string {
+	switch to - from {
+	case 0:
+		return fmt.Sprintf("%d,0", from)
+	case 1:
+		return fmt.Sprint(to)
+	}
+	return fmt.Sprintf("%d,%d", from+1, to-from)
+}
+
+// splitLines splits s after each newline. The last line has no newline
+// when s does not end with one.
+func splitLines(s string) []string {
+	lines := strings.SplitAfter(s, "\n")
+	if lines[len(lines)-1] == "" {
+		lines = lines[:len(lines)-1]
+	}
+	return lines
+}
+
+// diffLines returns a shortest edit script turning a into b. Lines that
+// appear on one side only can never be shared, so they are set aside
+// before comparing the rest with the linear space variant of Myers'
+// algorithm. Within a run of changes, removed lines come first.
+func diffLines(a, b []string) []edit {
+	ids := map[string]int{}
+	id := func(lines []string) []int {
+		out := make([]int, len(lines))
+		for i, l := range lines {
+			if _, ok := ids[l]; !ok {
+				ids[l] = len(ids)
+			}
+			out[i] = ids[l]
+		}
+		return out
+	}
+	aIDs, bIDs := id(a), id(b)
+	inA, inB := make([]bool, len(ids)), make([]bool, len(ids))
+	for _, x := range aIDs {
+		inA[x] = true
+	}
+	for _, y := range bIDs {
+		inB[y] = true
+	}
+
+	d := &differ{aMatch: make([]bool, len(a)), bMatch: make([]bool, len(b))}
+	for i, x := range aIDs {
+		if inB[x] {
+			d.a, d.aIdx = append(d.a, x), append(d.aIdx, i)
+		}
+	}
+	for j, y := range bIDs {
+		if inA[y] {
+			d.b, d.bIdx = append(d.b, y), append(d.bIdx, j)
+		}
+	}
+	size := 2*(len(d.a)+len(d.b)) + 4
+	d.vf, d.vb = make([]int, size), make([]int, size)
+	d.compare(0, len(d.a), 0, len(d.b))
+
+	var edits []edit
+	for i, j := 0, 0; i < len(a) || j < len(b); {
+		switch {
+		case i < len(a) && !d.aMatch[i]:
+			edits = append(edits, edit{'-', a[i]})
+			i++
+		case j < len(b) && !d.bMatch[j]:
+			edits = append(edits, edit{'+', b[j]})
+			j++
+		default:
+			edits = append(edits, edit{' ', a[i]})
+			i++
+			j++
+		}
+	}
+	return edits
+}
+
+// differ finds the lines a and b share. a and b hold line ids, aIdx and
+// bIdx where each line is in the original text, and aMatch and bMatch
+// mark the original lines found on both sides. vf and vb are the furthest
+// reaching paths of the forward and backward searches.
+type differ struct {
+	a, b           []int
+	aIdx, bIdx     []int
+	aMatch, bMatch []bool
+	vf, vb         []int
+}
+
+// compare marks the longest common subsequence of a[a0:a1] and b[b0:b1].
+func (d *differ) compare(a0, a1, b0, b1 int) {
+	for a0 < a1 && b0 < b1 && d.a[a0] == d.b[b0] {
+		d.match(a0, b0)
+		a0, b0 = a0+1, b0+1
+	}
+	for a0 < a1 && b0 < b1 && d.a[a1-1] == d.b[b1-1] {
+		d.match(a1-1, b1-1)
+		a1, b1 = a1-1, b1-1
+	}
+	if a0 == a1 || b0 == b1 {
+		return
+	}
+	x, y, u, v := d.middleSnake(a0, a1, b0, b1)
+	for i := range u - x {
+		d.match(x+i, y+i)
+	}
+	d.compare(a0, x, b0, y)
+	d.compare(u, a1, v, b1)
+}
+
+func (d *differ) match(i, j int) {
+	d.aMatch[d.aIdx[i]] = true
+	d.bMatch[d.bIdx[j]] = true
+}
+
+// middleSnake returns the diagonal run from (x, y) to (u, v) in the middle
+// of a shortest path from (a0, b0) to (a1, b1), found by searching from
+// both ends at once until the two searches overlap.
+func (d *differ) middleSnake(a0, a1, b0, b1 int) (x, y, u, v int) {
+	n, m := a1-a0, b1-b0
+	delta := n - m
+	off := n + m + 1
+	d.vf[off+1], d.vb[off+1] = 0, 0
+	for e := 0; e <= (n+m+1)/2; e++ {
+		for k := -e; k <= e; k += 2 {
+			var px int
+			if k == -e || k != e && d.vf[off+k-1] < d.vf[off+k+1] {
+				px = d.vf[off+k+1]
+			} else {
+				px = d.vf[off+k-1] + 1
+			}
+			py := px - k
+			sx, sy := px, py
+			for px < n && py < m && d.a[a0+px] == d.b[b0+py] {
+				px, py = px+1, py+1
+			}
+			d.vf[off+k] = px
+			// The backward search on diagonal delta-k has made e-1 edits.
+			if kb := delta - k; delta%2 != 0 && kb >= -(e-1) && kb <= e-1 && px+d.vb[off+kb] >= n {
+				return a0 + sx, b0 + sy, a0 + px, b0 + py
+			}
+		}
+		for k := -e; k <= e; k += 2 {
+			var px int
+			if k == -e || k != e && d.vb[off+k-1] < d.vb[off+k+1] {
+				px = d.vb[off+k+1]
+			} else {
+				px = d.vb[off+k-1] + 1
+			}
+			py := px - k
+			sx, sy := px, py
+			for px < n && py < m && d.a[a1-px-1] == d.b[b1-py-1] {
+				px, py = px+1, py+1
+			}
+			d.vb[off+k] = px
+			if kf := delta - k; delta%2 == 0 && kf >= -e && kf <= e && px+d.vf[off+kf] >= n {
+				return a1 - px, b1 - py, a1 - sx, b1 - sy
+			}
+		}
+	}
+	panic("unreachable")
+}
@@ -0,0 +1,344 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"flag"
+	"io"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+
+	"go_reloded/reloaded"
+)
+
+// TestMain runs main instead of the tests when GO_RELODED_MAIN is set, so
+// tests can start the test binary again to check how the command exits.
+func TestMain(m *testing.M) {
+	if os.Getenv("GO_RELODED_MAIN") == "1" {
+		main()
+		os.Exit(0)
+	}
+	os.Exit(m.Run())
+}
+
+func TestSample(t *testing.T) {
+	input, err := os.ReadFile("sample.txt")
+	if err != nil {
+		t.Fatal(err)
+	}
+	want, err := os.ReadFile("result.txt")
+	if err != nil {
+		t.Fatal(err)
+	}
+	got, err := reloaded.ProcessString(string(input), reloaded.Options{})
+	if err != nil || got != string(want) {
+		t.Errorf("got %q, %v, want %q", got, err, want)
+	}
+}
+
+func TestApplyConfig(t *testing.T) {
+	name := filepath.Join(t.TempDir(), "go_reloded.conf")
+	write := func(conf string) {
+		if err := os.WriteFile(name, []byte(conf), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	newFlags := func() (*flag.FlagSet, *string, *bool, *bool) {
+		fs := flag.NewFlagSet("test", flag.ContinueOnError)
+		return fs, fs.String("passes", "", ""), fs.Bool("strict", false, ""), fs.Bool("trace", false, "")
+	}
+
+	write("# tidy only\npasses = punctuation, quotes\nstrict = true # fail fast\n\ntrace=true\n")
+	fs, passes, strict, trace := newFlags()
+	if err := fs.Parse([]string{"--trace=false"}); err != nil {
+		t.Fatal(err)
+	}
+	if err := applyConfig(fs, name); err != nil {
+		t.Fatal(err)
+	}
+	if *passes != "punctuation, quotes" || !*strict || *trace {
+		t.Errorf("got passes=%q strict=%v trace=%v", *passes, *strict, *trace)
+	}
+
+	for _, bad := range []string{"passes\n", "colour = red\n", "strict = maybe\n"} {
+		write(bad)
+		fs, _, _, _ := newFlags()
+		if err := applyConfig(fs, name); err == nil {
+			t.Errorf("config %q accepted", bad)
+		}
+	}
+}
+
+func TestTransformHandler(t *testing.T) {
+	body := `{"text": "it (cap) was a apple (up) ,right ?", "passes": ["modifiers", "articles"]}`
+	rec := httptest.NewRecorder()
+	transformHandler(rec, httptest.NewRequest("POST", "/transform", strings.NewReader(body)))
+	if rec.Code != 200 {
+		t.Fatalf("status %d: %s", rec.Code, rec.Body)
+	}
+	var got Transformation
+	if err := json.NewDecoder(rec.Body).Decode(&got); err != nil {
+		t.Fatal(err)
+	}
+	if got.Result != "It was an APPLE ,right ?" || len(got.Trace) != 3 || got.Trace[0].Msg != "(cap) it → It" {
+		t.Errorf("got %+v", got)
+	}
+
+	for _, bad := range []string{`{"text": 1}`, `{"text": "x", "passes": ["nope"]}`, `not json`} {
+		rec := httptest.NewRecorder()
+		transformHandler(rec, httptest.NewRequest("POST", "/transform", strings.NewReader(bad)))
+		if rec.Code != 400 {
+			t.Errorf("%s: status %d", bad, rec.Code)
+		}
+	}
+	rec = httptest.NewRecorder()
+	transformHandler(rec, httptest.NewRequest("GET", "/transform", nil))
+	if rec.Code != 405 {
+		t.Errorf("GET: status %d", rec.Code)
+	}
+}
+
+func TestPlaygroundHandler(t *testing.T) {
+	form := url.Values{"text": {"<b>a</b> apple (up)"}, "pass": {"modifiers"}}
+	req := httptest.NewRequest("POST", "/", strings.NewReader(form.Encode()))
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	rec := httptest.NewRecorder()
+	playgroundHandler(rec, req)
+	if rec.Code != 200 {
+		t.Fatalf("status %d: %s", rec.Code, rec.Body)
+	}
+	page := rec.Body.String()
+	for _, want := range []string{"&lt;b&gt;a&lt;/b&gt; APPLE", "(up) apple → APPLE", `value="articles">`} {
+		if !strings.Contains(page, want) {
+			t.Errorf("page does not contain %q", want)
+		}
+	}
+
+	rec = httptest.NewRecorder()
+	playgroundHandler(rec, httptest.NewRequest("GET", "/missing", nil))
+	if rec.Code != 404 {
+		t.Errorf("GET /missing: status %d", rec.Code)
+	}
+}
+
+func TestRunBatch(t *testing.T) {
+	in, out := t.TempDir(), t.TempDir()
+	files := map[string]string{
+		"a.txt":         "hello (up) ,world",
+		"sub/b.txt":     "a apple",
+		"sub/deep/c.md": "bad (up, x) one",
+	}
+	for name, text := range files {
+		path := filepath.Join(in, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(text), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := os.Mkdir(filepath.Join(in, "empty"), 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	var log strings.Builder
+	failed, err := runBatch(in, out, 2, runSettings{strict: true}, &log)
+	if err != nil || failed != 1 {
+		t.Fatalf("failed = %d, err = %v", failed, err)
+	}
+	for name, want := range map[string]string{"a.txt": "HELLO, world", "sub/b.txt": "an apple"} {
+		got, err := os.ReadFile(filepath.Join(out, name))
+		if err != nil || string(got) != want {
+			t.Errorf("%s: got %q, %v, want %q", name, got, err, want)
+		}
+	}
+	if _, err := os.Stat(filepath.Join(out, "sub/deep/c.md")); err == nil {
+		t.Error("failed file was written")
+	}
+	if info, err := os.Stat(filepath.Join(out, "empty")); err != nil || !info.IsDir() {
+		t.Errorf("empty directory not mirrored: %v", err)
+	}
+	for _, want := range []string{"sub/deep/c.md: warning: line 1:5:", "sub/deep/c.md: Error:", "2 file(s) transformed, 1 failed"} {
+		if !strings.Contains(log.String(), filepath.FromSlash(want)) {
+			t.Errorf("log does not contain %q:\n%s", want, log.String())
+		}
+	}
+
+	if _, err := runBatch(in, filepath.Join(in, "out"), 2, runSettings{}, &log); err == nil {
+		t.Error("output inside input accepted")
+	}
+}
+
+func TestUnifiedDiff(t *testing.T) {
+	seq := func(lines ...string) string { return strings.Join(lines, "\n") + "\n" }
+	var from, to []string
+	for i := 1; i <= 20; i++ {
+		from = append(from, strconv.Itoa(i))
+		switch i {
+		case 2:
+			to = append(to, "two")
+		case 8:
+			to = append(to, "eight")
+		case 18:
+		default:
+			to = append(to, strconv.Itoa(i))
+		}
+	}
+	tests := []struct {
+		name, a, b, want string
+	}{
+		{"equal", seq("a", "b"), seq("a", "b"), ""},
+		{"insert", seq("a", "c"), seq("a", "b", "c"), "--- a\n+++ b\n@@ -1,2 +1,3 @@\n a\n+b\n c\n"},
+		{"insert into empty", "", seq("x"), "--- a\n+++ b\n@@ -0,0 +1 @@\n+x\n"},
+		{"delete", seq("a", "b", "c"), seq("a", "c"), "--- a\n+++ b\n@@ -1,3 +1,2 @@\n a\n-b\n c\n"},
+		{"delete all", seq("x", "y"), "", "--- a\n+++ b\n@@ -1,2 +0,0 @@\n-x\n-y\n"},
+		{"no newline at end", "a\nb", "a\nc", "--- a\n+++ b\n@@ -1,2 +1,2 @@\n a\n-b\n\\ No newline at end of file\n+c\n\\ No newline at end of file\n"},
+		{"hunks", seq(from...), seq(to...), "--- a\n+++ b\n" +
+			"@@ -1,11 +1,11 @@\n 1\n-2\n+two\n 3\n 4\n 5\n 6\n 7\n-8\n+eight\n 9\n 10\n 11\n" +
+			"@@ -15,6 +15,5 @@\n 15\n 16\n 17\n-18\n 19\n 20\n"},
+	}
+	for _, tt := range tests {
+		if got := unifiedDiff("a", "b", tt.a, tt.b); got != tt.want {
+			t.Errorf("%s: got\n%s\nwant\n%s", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestDryRun(t *testing.T) {
+	dir := t.TempDir()
+	upToDate := filepath.Join(dir, "done.txt")
+	if err := os.WriteFile(upToDate, []byte("IT works\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		in, out string
+		want    bool
+	}{
+		{"it (up) works\n", filepath.Join(dir, "missing.txt"), true},
+		{"it (up) works\n", upToDate, false},
+		{"it (low) works\n", upToDate, true},
+		{"it (up) works\n", "-", true},
+		{"IT works\n", "-", false},
+	}
+	for _, tt := range tests {
+		var diff strings.Builder
+		changed, err := dryRun(strings.NewReader(tt.in), "in", tt.out, reloaded.Options{}, true, &diff)
+		if err != nil || changed != tt.want {
+			t.Errorf("%q to %s: changed = %v, %v, want %v", tt.in, tt.out, changed, err, tt.want)
+		}
+		if wantDiff := tt.in != "IT works\n"; (diff.Len() > 0) != wantDiff {
+			t.Errorf("%q to %s: diff %q", tt.in, tt.out, diff.String())
+		}
+	}
+	if _, err := os.Stat(filepath.Join(dir, "missing.txt")); err == nil {
+		t.Error("dry run wrote the output file")
+	}
+}
+
+func TestWriteOutput(t *testing.T) {
+	dir := t.TempDir()
+	text := func(s string) func(w io.Writer) error {
+		return func(w io.Writer) error {
+			_, err := io.WriteString(w, s)
+			return err
+		}
+	}
+	check := func(name, want string, mode os.FileMode) {
+		t.Helper()
+		info, err := os.Lstat(name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		got, _ := os.ReadFile(name)
+		if string(got) != want || info.Mode() != mode {
+			t.Errorf("%s: %q with mode %v, want %q with mode %v", filepath.Base(name), got, info.Mode(), want, mode)
+		}
+	}
+
+	created := filepath.Join(dir, "new.txt")
+	if err := writeOutput(created, text("new")); err != nil {
+		t.Fatal(err)
+	}
+	check(created, "new", 0644)
+
+	private := filepath.Join(dir, "private.txt")
+	if err := os.WriteFile(private, []byte("old"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	if err := writeOutput(private, text("new")); err != nil {
+		t.Fatal(err)
+	}
+	check(private, "new", 0600)
+
+	target := filepath.Join(dir, "target.txt")
+	link := filepath.Join(dir, "link.txt")
+	if err := os.WriteFile(target, []byte("old"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Symlink(target, link); err != nil {
+		t.Skip("symlinks not supported:", err)
+	}
+	if err := writeOutput(link, text("through")); err != nil {
+		t.Fatal(err)
+	}
+	check(target, "through", 0644)
+	if info, err := os.Lstat(link); err != nil || info.Mode()&os.ModeSymlink == 0 {
+		t.Errorf("symlink replaced: %v", err)
+	}
+
+	if err := writeOutput(private, func(io.Writer) error { return errors.New("broken") }); err == nil {
+		t.Error("failed write succeeded")
+	}
+	check(private, "new", 0600)
+}
+
+func TestCheckExitStatus(t *testing.T) {
+	dir := t.TempDir()
+	write := func(name, text string) string {
+		path := filepath.Join(dir, name)
+		if err := os.WriteFile(path, []byte(text), 0644); err != nil {
+			t.Fatal(err)
+		}
+		return path
+	}
+	in := write("in.txt", "it (up) works\n")
+	done := write("done.txt", "IT works\n")
+	stale := write("stale.txt", "old\n")
+
+	tests := []struct {
+		args  []string
+		stdin string
+		want  int
+	}{
+		{[]string{"--check", in, done}, "", 0},
+		{[]string{"--check", in, stale}, "", 1},
+		{[]string{"--check", in, filepath.Join(dir, "missing.txt")}, "", 1},
+		{[]string{"--check", "-", "-"}, "already fine\n", 0},
+		{[]string{"--check", "-", "-"}, "it (up) works\n", 1},
+	}
+	for _, tt := range tests {
+		cmd := exec.Command(os.Args[0], tt.args...)
+		cmd.Env = append(os.Environ(), "GO_RELODED_MAIN=1")
+		cmd.Stdin = strings.NewReader(tt.stdin)
+		out, err := cmd.CombinedOutput()
+		code := 0
+		var exitErr *exec.ExitError
+		if errors.As(err, &exitErr) {
+			code = exitErr.ExitCode()
+		} else if err != nil {
+			t.Fatal(err)
+		}
+		if code != tt.want {
+			t.Errorf("%v: exit status %d, want %d\n%s", tt.args, code, tt.want, out)
+		}
+	}
+	if got, _ := os.ReadFile(stale); string(got) != "old\n" {
+		t.Errorf("--check rewrote %s: %q", stale, got)
+	}
+}
@@ -1,241 +1,240 @@
 package main
 
 import (
+	"bufio"
+	"cmp"
+	"errors"
+	"flag"
 	"fmt"
+	"io"
+	"io/fs"
 	"os"
-	"strconv"
+	"path/filepath"
+	"runtime"
+	"slices"
 	"strings"
+
+	"go_reloded/reloaded"
 )
 
+// go_reloded is a thin command line wrapper around package reloaded.
+// Either file may be "-" for stdin or stdout, so it can be used as a
+// filter: cat in.txt | go_reloded - -
+//
+// --diff and --check are dry runs: the output file is left alone. With -r
+// both arguments are directories and every file is transformed.
+// "go_reloded serve" starts the browser playground instead.
 func main() {
-	if len(os.Args) != 3 {
-		fmt.Println("USAGE: go run . <input-file> <output-file>")
+	if len(os.Args) > 1 && os.Args[1] == "serve" {
+		serveMain(os.Args[2:])
 		return
 	}
-	content, err := readFile(os.Args[1])
-	if err != nil {
-		fmt.Printf("Error reading file: %v\n", err)
+	strict := flag.Bool("strict", false, "fail on malformed modifiers instead of warning")
+	diff := flag.Bool("diff", false, "print a unified diff from input to output instead of writing it")
+	trace := flag.Bool("trace", false, "list every rule applied, with its position")
+	check := flag.Bool("check", false, "exit with status 1 if the output file, or for - the input, would change, without writing it")
+	passList := flag.String("passes", strings.Join(reloaded.DefaultPasses(), ","), "comma separated passes to run, in order")
+	configFile := flag.String("config", "", "read flag settings from `file`")
+	recursive := flag.Bool("r", false, "transform every file of the input directory into the output directory")
+	workers := flag.Int("j", runtime.NumCPU(), "number of files transformed at once with -r")
+	flag.Parse()
+	if flag.NArg() != 2 {
+		fmt.Fprintln(os.Stderr, "USAGE: go run . [flags] <input-file|-> <output-file|->")
+		fmt.Fprintln(os.Stderr, "       go run . -r [flags] <input-dir> <output-dir>")
+		flag.PrintDefaults()
 		return
 	}
-	final := processText(content)
-	err = writeFile(os.Args[2], final)
+	if *configFile != "" {
+		if err := applyConfig(flag.CommandLine, *configFile); err != nil {
+			fmt.Fprintf(os.Stderr, "Error reading config: %v\n", err)
+			os.Exit(1)
+		}
+	}
+	pipeline, err := reloaded.ParsePipeline(*passList)
 	if err != nil {
-		fmt.Printf("Error writing file: %v\n", err)
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+	run := runSettings{pipeline: pipeline, strict: *strict, trace: *trace}
+
+	if *recursive {
+		if *diff || *check {
+			fmt.Fprintln(os.Stderr, "Error: --diff and --check cannot be used with -r")
+			os.Exit(1)
+		}
+		if failed, err := runBatch(flag.Arg(0), flag.Arg(1), *workers, run, os.Stderr); err != nil || failed > 0 {
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			}
+			os.Exit(1)
+		}
 		return
 	}
-}
 
-func readFile(filename string) (string, error) {
-	data, err := os.ReadFile(filename)
+	in, err := openInput(flag.Arg(0))
 	if err != nil {
-		return "", fmt.Errorf("Error: %v", err)
+		fmt.Fprintf(os.Stderr, "Error reading file: %v\n", err)
+		os.Exit(1)
+	}
+	defer in.Close()
+
+	opts, rep := run.options()
+	changed := false
+	if *diff || *check {
+		changed, err = dryRun(in, flag.Arg(0), flag.Arg(1), opts, *diff, os.Stdout)
+	} else {
+		err = writeOutput(flag.Arg(1), func(w io.Writer) error {
+			return reloaded.Process(in, w, opts)
+		})
+	}
+	rep.print(os.Stderr, "")
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+	if *check && changed {
+		fmt.Fprintf(os.Stderr, "%s would change\n", flag.Arg(1))
+		os.Exit(1)
 	}
-	return string(data), nil
 }
 
-func writeFile(filename string, content string) error {
-	return os.WriteFile(filename, []byte(content), 0644)
+// runSettings are the command line settings that shape one run of the
+// pipeline.
+type runSettings struct {
+	pipeline reloaded.Pipeline
+	strict   bool
+	trace    bool
 }
 
-func processText(text string) string {
-	result := strings.Fields(text)
-	result = processModifiers(result)
-	result = fixPunctuation(result)
-	result = fixQuotes(result)
-	result = fixArticles(result)
-	return strings.Join(result, " ")
+// report collects what one run of the pipeline reported.
+type report struct {
+	warnings []reloaded.Warning
+	steps    []reloaded.Step
 }
 
-func hexToDecimal(s string) string {
-	dec, err := strconv.ParseInt(s, 16, 64)
-	if err != nil {
-		return s
+// options returns the options for one run, which report into rep.
+func (s runSettings) options() (opts reloaded.Options, rep *report) {
+	rep = &report{}
+	options := []reloaded.Option{
+		reloaded.WithPipeline(s.pipeline),
+		reloaded.WithWarnings(func(w reloaded.Warning) { rep.warnings = append(rep.warnings, w) }),
 	}
-	return strconv.Itoa(int(dec))
-}
-
-func binToDecimal(s string) string {
-	dec, err := strconv.ParseInt(s, 2, 64)
-	if err != nil {
-		return s
+	if s.strict {
+		options = append(options, reloaded.WithStrict())
 	}
-	return strconv.Itoa(int(dec))
-}
-
-func processModifiers(words []string) []string {
-	result := []string{}
-
-	for i := 0; i < len(words); i++ {
-		word := words[i]
-		switch word {
-		case "(hex)":
-			if len(result) > 0 {
-				result[len(result)-1] = hexToDecimal(result[len(result)-1])
-			}
-		case "(bin)":
-			if len(result) > 0 {
-				result[len(result)-1] = binToDecimal(result[len(result)-1])
-			}
-		case "(up)":
-			if len(result) > 0 {
-				result[len(result)-1] = strings.ToUpper(result[len(result)-1])
-			}
-		case "(low)":
-			if len(result) > 0 {
-				result[len(result)-1] = strings.ToLower(result[len(result)-1])
-			}
-		case "(cap)":
-			if len(result) > 0 {
-				result[len(result)-1] = capitalize(result[len(result)-1])
-			}
-		case "(up,":
-			if len(result) > 0 && i+1 < len(words) {
-				nextWord := words[i+1]
-
-				if before, ok := strings.CutSuffix(nextWord, ")"); ok {
-					n, err := strconv.Atoi(before)
-					if err == nil {
-						if n > len(result) {
-							n = len(result)
-						}
-						for j := 0; j < n; j++ {
-							idx := len(result) - 1 - j
-							result[idx] = strings.ToUpper(result[idx])
-						}
-						i++
-					}
-				}
-			}
-		case "(low,":
-			if len(result) > 0 && i+1 < len(words) {
-				nextWord := words[i+1]
-
-				if before, ok := strings.CutSuffix(nextWord, ")"); ok {
-					n, err := strconv.Atoi(before)
-					if err == nil {
-						if n > len(result) {
-							n = len(result)
-						}
-						for j := 0; j < n; j++ {
-							idx := len(result) - 1 - j
-							result[idx] = strings.ToLower(result[idx])
-						}
-						i++
-					}
-				}
-			}
-		case "(cap,":
-			if len(result) > 0 && i+1 < len(words) {
-				nextWord := words[i+1]
-
-				if before, ok := strings.CutSuffix(nextWord, ")"); ok {
-					n, err := strconv.Atoi(before)
-					if err == nil {
-						if n > len(result) {
-							n = len(result)
-						}
-						for j := 0; j < n; j++ {
-							idx := len(result) - 1 - j
-							result[idx] = capitalize(result[idx])
-						}
-						i++
-					}
-				}
-			}
-		default:
-			result = append(result, word)
-		}
+	if s.trace {
+		options = append(options, reloaded.WithTrace(func(st reloaded.Step) { rep.steps = append(rep.steps, st) }))
 	}
-
-	return result
+	return reloaded.NewOptions(options...), rep
 }
 
-func capitalize(s string) string {
-	if len(s) == 0 {
-		return s
+// print writes the steps in text order, then the warnings, each line
+// starting with prefix.
+func (r *report) print(w io.Writer, prefix string) {
+	sortSteps(r.steps)
+	for _, s := range r.steps {
+		fmt.Fprintf(w, "%s%v\n", prefix, s)
+	}
+	for _, warn := range r.warnings {
+		fmt.Fprintf(w, "%swarning: %v\n", prefix, warn)
 	}
-	return strings.ToUpper(s[:1]) + strings.ToLower(s[1:])
 }
 
-func fixPunctuation(words []string) []string {
-	var result []string
-
-	for _, word := range words {
-		if len(word) > 0 && isPunctuation(word[0]) {
-			i := 0
-			for i < len(word) && isPunctuation(word[i]) {
-				i++
-			}
-			puncPart := word[:i]
-			restPart := word[i:]
-
-			if len(result) > 0 {
-				result[len(result)-1] += puncPart
-			} else {
-				result = append(result, puncPart)
-			}
+// sortSteps puts traced steps back in text order, since the passes run
+// one after another.
+func sortSteps(steps []reloaded.Step) {
+	slices.SortStableFunc(steps, func(a, b reloaded.Step) int {
+		return cmp.Or(cmp.Compare(a.Line, b.Line), cmp.Compare(a.Col, b.Col))
+	})
+}
 
-			if restPart != "" {
-				result = append(result, restPart)
-			}
-		} else {
-			result = append(result, word)
-		}
+// dryRun transforms in without writing the output file. With diff it
+// writes a unified diff from the input to the result to w. It reports
+// whether the output file differs from the result, or, when the output is
+// stdout, whether the result differs from the input.
+func dryRun(in io.Reader, inName, outName string, opts reloaded.Options, diff bool, w io.Writer) (bool, error) {
+	input, err := io.ReadAll(in)
+	if err != nil {
+		return false, err
+	}
+	result, err := reloaded.ProcessString(string(input), opts)
+	if err != nil {
+		return false, err
+	}
+	if diff {
+		io.WriteString(w, unifiedDiff(inName, outName, string(input), result))
+	}
+	if outName == "-" {
+		return string(input) != result, nil
+	}
+	current, err := os.ReadFile(outName)
+	if errors.Is(err, fs.ErrNotExist) {
+		return true, nil
 	}
-	return result
+	if err != nil {
+		return false, err
+	}
+	return string(current) != result, nil
 }
 
-func isPunctuation(r byte) bool {
-	return r == '.' || r == '!' || r == '?' || r == ':' || r == ';' || r == ','
+func openInput(name string) (io.ReadCloser, error) {
+	if name == "-" {
+		return io.NopCloser(os.Stdin), nil
+	}
+	return os.Open(name)
 }
 
-func fixQuotes(words []string) []string {
-	var result []string
-	isOpenQuote := false
-	for _, word := range words {
-		if word == "'" {
-			if !isOpenQuote {
-				result = append(result, word)
-				isOpenQuote = true
-			} else {
-				if len(result) > 0 {
-					result[len(result)-1] += "'"
-				}
-				isOpenQuote = false
-			}
-		} else {
-			if isOpenQuote && len(result) > 0 && result[len(result)-1] == "'" {
-				result[len(result)-1] += word
-			} else {
-				result = append(result, word)
-			}
+// writeOutput runs write against the named file, or stdout for "-". A
+// regular file is written to a temporary name first and only replaces the
+// target, keeping its mode, once write succeeds. Anything else, such as a
+// symlink or /dev/null, is written through.
+func writeOutput(name string, write func(io.Writer) error) error {
+	if name == "-" {
+		w := bufio.NewWriter(os.Stdout)
+		if err := write(w); err != nil {
+			w.Flush()
+			return err
 		}
+		return w.Flush()
 	}
-	return result
-}
-
-func fixArticles(words []string) []string {
-	result := make([]string, len(words))
-	copy(result, words)
-	for i := 0; i < len(result)-1; i++ {
-		nextWord := result[i+1]
-		if result[i] == "a" || result[i] == "A" {
-			if len(nextWord) > 0 {
-				firstChar := rune(strings.ToLower(nextWord)[0])
-				if isVowelOrH(firstChar) {
-					if result[i] == "a" {
-						result[i] = "an"
-					} else {
-						result[i] = "An"
-					}
-				}
-			}
+	mode := fs.FileMode(0644)
+	info, err := os.Lstat(name)
+	switch {
+	case err == nil && !info.Mode().IsRegular():
+		f, err := os.OpenFile(name, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0644)
+		if err != nil {
+			return err
 		}
+		return writeFile(f, write)
+	case err == nil:
+		mode = info.Mode() & (fs.ModePerm | fs.ModeSetuid | fs.ModeSetgid | fs.ModeSticky)
+	case !errors.Is(err, fs.ErrNotExist):
+		return err
 	}
 
-	return result
+	f, err := os.CreateTemp(filepath.Dir(name), ".go_reloded-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(f.Name())
+	if err := writeFile(f, write); err != nil {
+		return err
+	}
+	if err := os.Chmod(f.Name(), mode); err != nil {
+		return err
+	}
+	return os.Rename(f.Name(), name)
 }
 
-func isVowelOrH(r rune) bool {
-	return r == 'a' || r == 'e' || r == 'i' || r == 'o' || r == 'u' || r == 'h'
+// writeFile runs write against f through a buffer and closes f.
+func writeFile(f *os.File, write func(io.Writer) error) error {
+	w := bufio.NewWriter(f)
+	err := write(w)
+	if err == nil {
+		err = w.Flush()
+	}
+	if cerr := f.Close(); err == nil {
+		err = cerr
+	}
+	return err
 }
@@ -0,0 +1,103 @@
+package reloaded
+
+import (
+	"strings"
+	"unicode"
+)
+
+// Words starting with a silent h take "an": "an hour", "an honest man".
+var silentH = []string{"heir", "honest", "honor", "honour", "hour"}
+
+// Words starting with a vowel letter but a consonant sound take "a":
+// "a unicorn", "a European", "a one-off".
+var consonantSound = []string{
+	"eu", "ewe", "one-", "uku", "unic", "unif", "unil", "union", "uniq", "unis", "unit", "univ",
+	"ura", "ure", "uri", "usa", "use", "usu", "ute", "uti", "uto",
+}
+
+var consonantSoundWords = []string{"one", "once", "ones"}
+
+// Letters whose name starts with a vowel sound, for acronyms read letter
+// by letter: "an FBI agent", "an MRI", "a UFO".
+const vowelSoundLetters = "AEFHILMNORSX"
+
+const vowels = "aeiouàáâäæèéêëìíîïòóôöœùúûü"
+
+// startsWithVowelSound reports whether word should follow "an" rather
+// than "a". Leading punctuation, as in "(apple" or `"apple`, is ignored.
+func startsWithVowelSound(word string) bool {
+	word = strings.TrimLeftFunc(word, func(r rune) bool { return !isAlnum(r) })
+	if word == "" {
+		return false
+	}
+	if isAcronym(word) {
+		return strings.IndexByte(vowelSoundLetters, word[0]) >= 0
+	}
+	lower := strings.ToLower(word)
+	first := []rune(lower)[0]
+	switch {
+	case unicode.IsDigit(first):
+		return numberStartsWithVowelSound(lower)
+	case first == 'h':
+		return hasAnyPrefix(lower, silentH)
+	case strings.ContainsRune(vowels, first):
+		return !hasAnyPrefix(lower, consonantSound) && !isAny(lower, consonantSoundWords)
+	}
+	return false
+}
+
+// numberStartsWithVowelSound handles numbers read aloud: "an 8", "an 11",
+// "an 18,000", but "a 110".
+func numberStartsWithVowelSound(s string) bool {
+	digits := s[:len(s)-len(strings.TrimLeftFunc(s, unicode.IsDigit))]
+	if digits[0] == '8' {
+		return true
+	}
+	return len(digits)%3 == 2 && (strings.HasPrefix(digits, "11") || strings.HasPrefix(digits, "18"))
+}
+
+// isAcronym reports whether word is read letter by letter: all capitals
+// and either short or without vowels, like "FBI" or "HTML".
+func isAcronym(word string) bool {
+	if !isAllCaps(word) {
+		return false
+	}
+	return len(word) <= 3 || !strings.ContainsAny(word, "AEIOU")
+}
+
+// isShouted reports whether word is an ordinary word written in capitals.
+func isShouted(word string) bool {
+	word = strings.TrimLeftFunc(word, func(r rune) bool { return !isAlnum(r) })
+	return isAllCaps(word) && !isAcronym(word)
+}
+
+func isAllCaps(word string) bool {
+	letters := 0
+	for _, r := range word {
+		if unicode.IsLower(r) {
+			return false
+		}
+		if unicode.IsLetter(r) {
+			letters++
+		}
+	}
+	return letters >= 2
+}
+
+func hasAnyPrefix(s string, prefixes []string) bool {
+	for _, p := range prefixes {
+		if strings.HasPrefix(s, p) {
+			return true
+		}
+	}
+	return false
+}
+
+func isAny(s string, words []string) bool {
+	for _, w := range words {
+		if s == w {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,89 @@
+package reloaded
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// modifier is a parsed "(name)" or "(name, count)".
+type modifier struct {
+	rule  rule
+	count int
+}
+
+// parseModifier reads a modifier at the start of s with the grammar
+//
+//	"(" space* name space* ["," space* count space*] ")"
+//
+// where space never crosses a line. ok is false when s does not start
+// with a registered rule name followed by ")" or by "," and a single
+// count token and ")", so "(see above)", "(up next)" and
+// "(low, medium or high)" stay plain text.
+// err is set when a known modifier is malformed, for example "(up, -2)"
+// or "(low, x)"; n then covers the malformed text so it can be dropped.
+func parseModifier(s string) (n int, m modifier, ok bool, err error) {
+	if !strings.HasPrefix(s, "(") {
+		return 0, m, false, nil
+	}
+	i := 1 + spanFunc(s[1:], isInlineSpaceRune)
+	nameLen := spanFunc(s[i:], isNameRune)
+	var known bool
+	m.rule, known = lookupRule(strings.ToLower(s[i : i+nameLen]))
+	if nameLen == 0 || !known {
+		return 0, modifier{}, false, nil
+	}
+	i += nameLen
+	i += spanFunc(s[i:], isInlineSpaceRune)
+	m.count = 1
+
+	switch {
+	case strings.HasPrefix(s[i:], ")"):
+		return i + 1, m, true, nil
+	case !strings.HasPrefix(s[i:], ","):
+		return 0, m, false, nil
+	}
+	i++
+	i += spanFunc(s[i:], isInlineSpaceRune)
+	countLen := spanFunc(s[i:], func(r rune) bool { return r != ')' && !unicode.IsSpace(r) })
+	countText := s[i : i+countLen]
+	i += countLen
+	i += spanFunc(s[i:], isInlineSpaceRune)
+	if !strings.HasPrefix(s[i:], ")") {
+		return 0, m, false, nil
+	}
+	count, convErr := strconv.Atoi(countText)
+	switch {
+	case m.rule.arity == 0:
+		err = fmt.Errorf("(%s) does not take a count", m.rule.name)
+	case countText == "":
+		err = fmt.Errorf("missing count")
+	case convErr != nil:
+		err = fmt.Errorf("count %q is not a number", countText)
+	case count < 1:
+		err = fmt.Errorf("count %d must be positive", count)
+	}
+	m.count = count
+	return i + 1, m, true, err
+}
+
+// isNameRune reports whether r can appear in a rule name, which is made
+// of letters and may contain an arrow, as in "dec→hex" or "dec->hex".
+func isNameRune(r rune) bool {
+	return unicode.IsLetter(r) || r == '→' || r == '-' || r == '>'
+}
+
+func isInlineSpaceRune(r rune) bool {
+	return unicode.IsSpace(r) && r != '\n'
+}
+
+// modifierLen returns the length of the modifier at the start of s, well
+// formed or not, or 0 if s does not start with one.
+func modifierLen(s string) int {
+	n, _, ok, _ := parseModifier(s)
+	if !ok {
+		return 0
+	}
+	return n
+}
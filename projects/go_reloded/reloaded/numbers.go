@@ -0,0 +1,46 @@
+package reloaded
+
+import (
+	"fmt"
+	"math/big"
+	"strings"
+)
+
+// basePrefixes are the prefixes accepted in front of a number in a base,
+// as in "0x1E" or "0b101".
+var basePrefixes = map[int]string{16: "0x", 8: "0o", 2: "0b"}
+
+var baseNames = map[int]string{16: "hexadecimal", 10: "decimal", 8: "octal", 2: "binary"}
+
+// parseNumber reads s as an integer of any size written in base, with an
+// optional sign and the prefix of the base.
+func parseNumber(s string, base int) (*big.Int, error) {
+	digits := s
+	neg := strings.HasPrefix(digits, "-")
+	if neg || strings.HasPrefix(digits, "+") {
+		digits = digits[1:]
+	}
+	if p := basePrefixes[base]; p != "" && len(digits) > len(p) && strings.EqualFold(digits[:len(p)], p) {
+		digits = digits[len(p):]
+	}
+	n, ok := new(big.Int).SetString(digits, base)
+	if !ok || strings.ContainsAny(digits[:1], "+-") {
+		return nil, fmt.Errorf("%q is not a %s number", s, baseNames[base])
+	}
+	if neg {
+		n.Neg(n)
+	}
+	return n, nil
+}
+
+// convertBase returns a rule function that rewrites a number from one
+// base to another. Digits above 9 are written in upper case.
+func convertBase(from, to int) func(string) (string, error) {
+	return func(s string) (string, error) {
+		n, err := parseNumber(s, from)
+		if err != nil {
+			return s, err
+		}
+		return strings.ToUpper(n.Text(to)), nil
+	}
+}
@@ -0,0 +1,189 @@
+package reloaded
+
+import (
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// processModifiers applies every modifier to the words before it and
+// removes it together with one of the spaces around it. Malformed
+// modifiers are reported and dropped. Words a rule cannot convert are
+// reported and left as they were.
+func processModifiers(tokens []Token, ctx *Context) []Token {
+	result := []Token{}
+
+	for i := 0; i < len(tokens); i++ {
+		tok := tokens[i]
+		if tok.Kind != Modifier {
+			result = append(result, tok)
+			continue
+		}
+		_, m, _, err := parseModifier(tok.Text)
+		n := m.count
+		if err != nil {
+			ctx.Warnf(tok, "malformed modifier %q: %v", tok.Text, err)
+			n = 0
+		}
+		for j := len(result) - 1; j >= 0 && n > 0; j-- {
+			if result[j].Kind == Word {
+				before := result[j].Text
+				after, err := m.rule.apply(before)
+				result[j].Text = after
+				if err != nil {
+					ctx.Warnf(result[j], "(%s): %v", m.rule.name, err)
+				} else if after != before {
+					ctx.Tracef(result[j], "%s %s → %s", tok.Text, before, result[j].Text)
+				}
+				n--
+			}
+		}
+
+		last := len(result) - 1
+		glued := i+1 < len(tokens) && tokens[i+1].Kind == Word
+		switch {
+		case last >= 0 && isInlineSpace(result[last]) && !glued:
+			result = result[:last]
+		case last >= 0 && result[last].Kind != Space:
+			// Glued to the previous word, as in "word(up)": keep the
+			// space that follows.
+		case i+1 < len(tokens) && isInlineSpace(tokens[i+1]):
+			i++
+		}
+	}
+
+	return result
+}
+
+// isInlineSpace reports whether t is whitespace that does not break the line.
+func isInlineSpace(t Token) bool {
+	return t.Kind == Space && !strings.Contains(t.Text, "\n")
+}
+
+// capitalize upper-cases the first letter of s and lower-cases the rest.
+// It works on runes, so "élan" becomes "Élan".
+func capitalize(s string) string {
+	r, size := utf8.DecodeRuneInString(s)
+	if size == 0 {
+		return s
+	}
+	return string(unicode.ToTitle(r)) + strings.ToLower(s[size:])
+}
+
+// fixPunctuation attaches every group of punctuation marks, such as ","
+// or "?!", to the word before it and leaves exactly one space between the
+// group and the word or opening quote after it. A group that starts a line
+// moves to the end of the line before. A line break after a group is
+// kept, without the spaces before it. Every group whose spacing changes is
+// traced.
+func fixPunctuation(tokens []Token, ctx *Context) []Token {
+	var result []Token
+
+	for i := 0; i < len(tokens); i++ {
+		tok := tokens[i]
+		if tok.Kind != Punct {
+			result = append(result, tok)
+			continue
+		}
+		var before Token
+		moved := false
+		last := len(result) - 1
+		switch {
+		case last >= 0 && isInlineSpace(result[last]):
+			before, moved = result[last], true
+			result = result[:last]
+		case last >= 1 && result[last].Kind == Space && result[last-1].Kind != Space:
+			// "first line\n, second": the group goes before the line break.
+			was := result[last].Text + tok.Text
+			lineBreak := trimLineBreak(result[last])
+			result = append(result[:last], tok, lineBreak)
+			if i+1 < len(tokens) && isInlineSpace(tokens[i+1]) {
+				was += tokens[i+1].Text
+				i++
+			}
+			ctx.Tracef(tok, "punctuation: '%s' → '%s'", visible(was), visible(tok.Text+lineBreak.Text))
+			continue
+		}
+		was := before.Text + tok.Text
+		start := len(result)
+		result = append(result, tok)
+
+		space := Token{Kind: Space, Text: " ", Line: tok.Line, Col: tok.Col + utf8.RuneCountInString(tok.Text)}
+		switch {
+		case i+1 < len(tokens) && tokens[i+1].Kind == Space && !isInlineSpace(tokens[i+1]):
+			was += tokens[i+1].Text
+			result = append(result, trimLineBreak(tokens[i+1]))
+			i++
+		case i+2 < len(tokens) && isInlineSpace(tokens[i+1]):
+			was += tokens[i+1].Text
+			space.Line, space.Col = tokens[i+1].Line, tokens[i+1].Col
+			result = append(result, space)
+			i++
+		case i+1 < len(tokens) && (tokens[i+1].Kind == Word || opensQuote(tokens, i+1)):
+			result = append(result, space)
+		case moved && i+1 < len(tokens) && tokens[i+1].Kind != Space:
+			// "word ,' quoted '": the space moves after the comma.
+			result = append(result, before)
+		}
+		if now := render(result[start:]); now != was {
+			ctx.Tracef(tok, "punctuation: '%s' → '%s'", visible(was), visible(now))
+		}
+	}
+	return result
+}
+
+// visible spells out the line breaks and tabs of s for a trace message.
+func visible(s string) string {
+	return strings.NewReplacer("\r", `\r`, "\n", `\n`, "\t", `\t`).Replace(s)
+}
+
+// trimLineBreak drops the spaces and tabs before the line break in t.
+func trimLineBreak(t Token) Token {
+	t.Text = strings.TrimLeft(t.Text, " \t")
+	return t
+}
+
+// opensQuote reports whether the quote at tokens[i] opens a quotation.
+// Before fixQuotes has decided, a quote glued to the word after it is
+// taken to open one.
+func opensQuote(tokens []Token, i int) bool {
+	switch tokens[i].role {
+	case quoteOpen, quotePaired:
+		return true
+	case quoteUnknown:
+		return tokens[i].Kind == Quote && i+1 < len(tokens) && tokens[i+1].Kind == Word
+	}
+	return false
+}
+
+// fixArticles turns "a" into "an" before a word that starts with a vowel
+// sound. Quotes between the article and the word are skipped.
+func fixArticles(tokens []Token, ctx *Context) []Token {
+	result := make([]Token, len(tokens))
+	copy(result, tokens)
+	for i := 0; i+2 < len(result); i++ {
+		word := result[i]
+		if word.Kind != Word || (word.Text != "a" && word.Text != "A") || result[i+1].Kind != Space {
+			continue
+		}
+		j := i + 2
+		for j < len(result) && result[j].Kind == Quote {
+			j++
+		}
+		if j == len(result) || result[j].Kind != Word {
+			continue
+		}
+		next := result[j].Text
+		if !startsWithVowelSound(next) {
+			continue
+		}
+		if word.Text == "A" && isShouted(next) {
+			result[i].Text = "AN"
+		} else {
+			result[i].Text += "n"
+		}
+		ctx.Tracef(word, "article: %s → %s before '%s'", word.Text, result[i].Text, next)
+	}
+
+	return result
+}
@@ -0,0 +1,108 @@
+package reloaded
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// Pass is one transformation over the tokens. A pass must be safe to run
+// again over tokens it already processed, because the stream processor
+// re-runs each pass over the tail it keeps back. The registered passes
+// are "modifiers", "punctuation", "quotes" and "articles".
+type Pass interface {
+	Name() string
+	Run(tokens []Token, ctx *Context) []Token
+}
+
+// passFunc turns a function into a Pass.
+type passFunc struct {
+	name string
+	run  func([]Token, *Context) []Token
+}
+
+func (p passFunc) Name() string                             { return p.name }
+func (p passFunc) Run(tokens []Token, ctx *Context) []Token { return p.run(tokens, ctx) }
+
+var passes = map[string]Pass{}
+
+// defaultPasses is the order the spec applies the transformations in.
+var defaultPasses = []string{"modifiers", "punctuation", "quotes", "articles"}
+
+// DefaultPasses returns the names of the passes run by default, in order.
+func DefaultPasses() []string {
+	return slices.Clone(defaultPasses)
+}
+
+// registerPass makes p available to pipelines. Registering a name twice
+// replaces the earlier pass.
+func registerPass(p Pass) {
+	passes[p.Name()] = p
+}
+
+func init() {
+	registerPass(passFunc{"modifiers", processModifiers})
+	registerPass(passFunc{"punctuation", fixPunctuation})
+	registerPass(passFunc{"quotes", fixQuotes})
+	registerPass(passFunc{"articles", fixArticles})
+}
+
+// Pipeline is a sequence of passes run one after another.
+type Pipeline []Pass
+
+// NewPipeline builds a pipeline from pass names, in the order given.
+// Without names it returns the default pipeline.
+func NewPipeline(names ...string) (Pipeline, error) {
+	if len(names) == 0 {
+		names = defaultPasses
+	}
+	var p Pipeline
+	for i, name := range names {
+		pass, ok := passes[name]
+		if !ok {
+			return nil, fmt.Errorf("unknown pass %q (have %s)", name, strings.Join(passNames(), ", "))
+		}
+		if slices.Contains(names[:i], name) {
+			return nil, fmt.Errorf("pass %q listed twice", name)
+		}
+		p = append(p, pass)
+	}
+	return p, nil
+}
+
+// ParsePipeline builds a pipeline from a comma separated list of names
+// such as "modifiers,punctuation".
+func ParsePipeline(list string) (Pipeline, error) {
+	var names []string
+	for _, name := range strings.Split(list, ",") {
+		if name = strings.TrimSpace(name); name != "" {
+			names = append(names, name)
+		}
+	}
+	if len(names) == 0 {
+		return nil, fmt.Errorf("no passes in %q", list)
+	}
+	return NewPipeline(names...)
+}
+
+// Run applies every pass to tokens.
+func (p Pipeline) Run(tokens []Token, ctx *Context) []Token {
+	for _, pass := range p {
+		tokens = pass.Run(tokens, ctx)
+	}
+	return tokens
+}
+
+// passNames returns the registered pass names in default order, followed
+// by any others sorted by name.
+func passNames() []string {
+	names := slices.Clone(defaultPasses)
+	var extra []string
+	for name := range passes {
+		if !slices.Contains(names, name) {
+			extra = append(extra, name)
+		}
+	}
+	slices.Sort(extra)
+	return append(names, extra...)
+}
@@ -0,0 +1,103 @@
+package reloaded
+
+// quoteRole is what fixQuotes decided a Quote token is.
+type quoteRole int
+
+const (
+	quoteUnknown    quoteRole = iota
+	quoteOpen                 // opens a quotation that is not closed yet
+	quotePaired               // opens a quotation that was closed
+	quoteClose                // closes a quotation
+	quoteApostrophe           // a possessive, as in "the students' books"
+	quoteStray                // a closing quote with nothing open
+)
+
+// fixQuotes attaches quotation marks to the words they enclose. Single
+// and double quotes nest, so "she said 'hi'" pairs the inner quotes
+// first. Whether a quote opens or closes is decided from what touches it:
+// a quote glued to the word after it opens, one glued to the word or
+// punctuation before it closes, and a quote with space on both sides closes the innermost
+// open quote of the same kind or opens a new one. The open quotes are
+// kept in ctx across calls.
+//
+// The space after an opening quote is only removed once its closing quote
+// is found, so an unbalanced quote stays where it was and is reported by
+// finishQuotes instead of being glued to the wrong word.
+func fixQuotes(tokens []Token, ctx *Context) []Token {
+	var result []Token
+	for i := 0; i < len(tokens); i++ {
+		tok := tokens[i]
+		if tok.Kind != Quote || tok.role != quoteUnknown {
+			result = append(result, tok)
+			continue
+		}
+		last := len(result) - 1
+		leftTight := last >= 0 && result[last].Kind != Space
+		rightTight := i+1 < len(tokens) && tokens[i+1].Kind != Space && tokens[i+1].Kind != Punct
+		open := ctx.openQuote(tok.Text)
+
+		switch {
+		case open < 0 && leftTight && !rightTight && tok.Text == "'":
+			tok.role = quoteApostrophe
+		case open < 0 && leftTight && !rightTight:
+			tok.role = quoteStray
+			ctx.Warnf(tok, "closing %s has no opening quote", tok.Text)
+		case open < 0 || !leftTight && rightTight:
+			tok.role = quoteOpen
+			ctx.quotes = append(ctx.quotes, tok)
+		default:
+			tok.role = quoteClose
+			result = ctx.closeQuote(result, open, tok)
+			if last := len(result) - 1; last >= 0 && isInlineSpace(result[last]) {
+				result = result[:last]
+			}
+		}
+		result = append(result, tok)
+	}
+	return result
+}
+
+// openQuote returns the index in ctx.quotes of the innermost open quote
+// written as text, or -1.
+func (c *Context) openQuote(text string) int {
+	for i := len(c.quotes) - 1; i >= 0; i-- {
+		if c.quotes[i].Text == text {
+			return i
+		}
+	}
+	return -1
+}
+
+// closeQuote closes the open quote at index open of c.quotes with the
+// quote closing. Quotes opened inside it and never closed are reported.
+// When the opening quote is still among tokens, the space after it is
+// removed.
+func (c *Context) closeQuote(tokens []Token, open int, closing Token) []Token {
+	for _, q := range c.quotes[open+1:] {
+		c.Warnf(q, "%s is never closed", q.Text)
+	}
+	q := c.quotes[open]
+	c.quotes = c.quotes[:open]
+	c.Tracef(closing, "quotes: paired %s at %d:%d", q.Text, q.Line, q.Col)
+
+	for i := len(tokens) - 1; i >= 0; i-- {
+		t := tokens[i]
+		if t.Kind != Quote || t.role != quoteOpen || t.Line != q.Line || t.Col != q.Col {
+			continue
+		}
+		tokens[i].role = quotePaired
+		if i+1 < len(tokens) && isInlineSpace(tokens[i+1]) {
+			tokens = append(tokens[:i+1], tokens[i+2:]...)
+		}
+		break
+	}
+	return tokens
+}
+
+// finishQuotes reports the quotes still open at the end of the input.
+func (c *Context) finishQuotes() {
+	for _, q := range c.quotes {
+		c.Warnf(q, "%s is never closed", q.Text)
+	}
+	c.quotes = nil
+}
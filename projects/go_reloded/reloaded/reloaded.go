@@ -0,0 +1,143 @@
+// Package reloaded is the text clean-up engine of go_reloded. It applies
+// modifiers such as "(up)" or "(hex)" to the words before them, fixes the
+// spacing around punctuation and quotes, and turns "a" into "an" before a
+// vowel sound:
+//
+//	err := reloaded.Process(os.Stdin, os.Stdout, reloaded.NewOptions(
+//		reloaded.WithStrict(),
+//		reloaded.WithWarnings(func(w reloaded.Warning) { log.Print(w) }),
+//	))
+//
+// Input is streamed, so texts of any size are processed in a few megabytes
+// of memory. A line of more than a megabyte without a space may be cut
+// inside a word to stay within that.
+// Whitespace that no rule touches, including newlines and indentation, is
+// copied to the output unchanged.
+package reloaded
+
+import (
+	"fmt"
+	"io"
+	"strings"
+)
+
+// Options controls a run of Process. The zero value runs the default
+// pipeline and ignores problems in the input.
+type Options struct {
+	// Strict makes Process fail when the input has a problem, such as a
+	// malformed modifier, instead of only reporting it.
+	Strict bool
+	// Passes is the pipeline to run; nil runs the passes named by
+	// DefaultPasses.
+	Passes Pipeline
+	// Warn, when set, is called for every problem found in the input.
+	Warn func(Warning)
+	// Trace, when set, is called for every rule applied to the text.
+	Trace func(Step)
+}
+
+// Option sets a field of Options.
+type Option func(*Options)
+
+// NewOptions returns Options with every option applied in order.
+func NewOptions(opts ...Option) Options {
+	var o Options
+	for _, opt := range opts {
+		opt(&o)
+	}
+	return o
+}
+
+// WithStrict makes problems in the input an error.
+func WithStrict() Option {
+	return func(o *Options) { o.Strict = true }
+}
+
+// WithPipeline runs p instead of the default pipeline.
+func WithPipeline(p Pipeline) Option {
+	return func(o *Options) { o.Passes = p }
+}
+
+// WithWarnings calls f for every problem found in the input.
+func WithWarnings(f func(Warning)) Option {
+	return func(o *Options) { o.Warn = f }
+}
+
+// WithTrace calls f for every rule applied to the text.
+func WithTrace(f func(Step)) Option {
+	return func(o *Options) { o.Trace = f }
+}
+
+// Process transforms the text read from r and writes the result to w.
+// Problems in the input are passed to opts.Warn, and in strict mode they
+// also make Process fail. Output may already have been written when an
+// error is returned.
+func Process(r io.Reader, w io.Writer, opts Options) error {
+	return processStream(r, w, &Context{opts: opts})
+}
+
+// ProcessString is Process for a text held in memory.
+func ProcessString(text string, opts Options) (string, error) {
+	var sb strings.Builder
+	err := Process(strings.NewReader(text), &sb, opts)
+	return sb.String(), err
+}
+
+// Warning is a problem found in the input.
+type Warning struct {
+	Line int    `json:"line"`
+	Col  int    `json:"col"`
+	Msg  string `json:"msg"`
+}
+
+func (w Warning) String() string {
+	return fmt.Sprintf("line %d:%d: %s", w.Line, w.Col, w.Msg)
+}
+
+// Step is a rule applied to the text, reported when tracing.
+type Step struct {
+	Line int    `json:"line"`
+	Col  int    `json:"col"`
+	Msg  string `json:"msg"`
+}
+
+func (s Step) String() string {
+	return fmt.Sprintf("line %d: %s", s.Line, s.Msg)
+}
+
+// Context is shared by the passes of one run. It carries the options and
+// the state that has to survive from one chunk of input to the next.
+type Context struct {
+	opts     Options
+	warnings int
+	first    Warning
+
+	quotes []Token // quotes opened and not closed yet, innermost last
+}
+
+// Warnf reports a problem in the input at tok.
+func (c *Context) Warnf(tok Token, format string, args ...any) {
+	w := Warning{tok.Line, tok.Col, fmt.Sprintf(format, args...)}
+	if c.warnings == 0 {
+		c.first = w
+	}
+	c.warnings++
+	if c.opts.Warn != nil {
+		c.opts.Warn(w)
+	}
+}
+
+// Tracef reports a rule applied at tok when tracing is on.
+func (c *Context) Tracef(tok Token, format string, args ...any) {
+	if c.opts.Trace != nil {
+		c.opts.Trace(Step{tok.Line, tok.Col, fmt.Sprintf(format, args...)})
+	}
+}
+
+// err returns an error in strict mode when any warning was reported.
+func (c *Context) err() error {
+	if !c.opts.Strict || c.warnings == 0 {
+		return nil
+	}
+	return fmt.Errorf("%d problem(s) in input, first at %v", c.warnings, c.first)
+}
@@ -0,0 +1,513 @@
+package reloaded
+
+import (
+	"flag"
+	"io"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+	"testing/iotest"
+)
+
+var update = flag.Bool("update", false, "rewrite the golden files in testdata")
+
+// TestGolden runs every testdata/*.txt input through the pipeline and
+// compares the result with the .golden file next to it. Run with -update
+// to rewrite the golden files after an intended change.
+func TestGolden(t *testing.T) {
+	inputs, err := filepath.Glob(filepath.Join("testdata", "*.txt"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(inputs) == 0 {
+		t.Fatal("no inputs in testdata")
+	}
+	for _, in := range inputs {
+		name := strings.TrimSuffix(filepath.Base(in), ".txt")
+		t.Run(name, func(t *testing.T) {
+			input, err := os.ReadFile(in)
+			if err != nil {
+				t.Fatal(err)
+			}
+			got, err := ProcessString(string(input), Options{})
+			if err != nil {
+				t.Fatal(err)
+			}
+			golden := strings.TrimSuffix(in, ".txt") + ".golden"
+			if *update {
+				if err := os.WriteFile(golden, []byte(got), 0644); err != nil {
+					t.Fatal(err)
+				}
+				return
+			}
+			want, err := os.ReadFile(golden)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got != string(want) {
+				t.Errorf("output differs from %s:\ngot:  %q\nwant: %q", golden, got, want)
+			}
+
+			// Reading one byte at a time must not change the result.
+			var sb strings.Builder
+			if err := Process(iotest.OneByteReader(strings.NewReader(string(input))), &sb, Options{}); err != nil {
+				t.Fatal(err)
+			}
+			if sb.String() != got {
+				t.Errorf("streamed output differs:\ngot:  %q\nwant: %q", sb.String(), got)
+			}
+		})
+	}
+}
+
+func TestTokenize(t *testing.T) {
+	tokens := tokenize("it's (up, 2)  done ,\n\"ok\"")
+	want := []struct {
+		kind TokenKind
+		text string
+		line int
+		col  int
+	}{
+		{Word, "it's", 1, 1},
+		{Space, " ", 1, 5},
+		{Modifier, "(up, 2)", 1, 6},
+		{Space, "  ", 1, 13},
+		{Word, "done", 1, 15},
+		{Space, " ", 1, 19},
+		{Punct, ",", 1, 20},
+		{Space, "\n", 1, 21},
+		{Quote, `"`, 2, 1},
+		{Word, "ok", 2, 2},
+		{Quote, `"`, 2, 4},
+	}
+	if len(tokens) != len(want) {
+		t.Fatalf("got %d tokens: %+v", len(tokens), tokens)
+	}
+	for i, w := range want {
+		tok := tokens[i]
+		if tok.Kind != w.kind || tok.Text != w.text || tok.Line != w.line || tok.Col != w.col {
+			t.Errorf("token %d = %+v, want %+v", i, tok, w)
+		}
+	}
+
+	for _, text := range []string{
+		"",
+		"plain words",
+		"  leading and trailing  \n",
+		"tabs\tand\r\nCRLF\n\n",
+		"Ünïcödé (cap, 2) “curly” ,punct!?",
+		"(up) (low, x) (hex (bin))",
+		"don't 'quote' \"double\" 3.14 e.g.",
+	} {
+		if got := render(tokenize(text)); got != text {
+			t.Errorf("render(tokenize(%q)) = %q", text, got)
+		}
+	}
+}
+
+// TestStreamChunkBoundaries feeds texts longer than a chunk through
+// Process with readers that split them at different places, so modifiers,
+// counts and quotes end up on both sides of a chunk boundary.
+func TestStreamChunkBoundaries(t *testing.T) {
+	unit := "a apple (up, 2) ' ff (hex) ' ,x \"said\" it (cap)\n"
+	unitOut, err := ProcessString(unit, Options{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	n := 3*streamChunk/len(unit) + 1
+	// The first chunk ends inside "(up, 3)", whose count reaches back
+	// into it.
+	long := strings.Repeat("w ", streamChunk/2-3) + "x y (up, 3) end"
+	// "a apple" is in the first chunk and has already met the articles
+	// pass when (rev, 3) arrives, which must still see "a apple".
+	late := strings.Repeat("w ", streamChunk/2-5) + "a apple x (rev, 3)"
+	tests := []struct{ in, want string }{
+		{strings.Repeat(unit, n), strings.Repeat(unitOut, n)},
+		{long, strings.Repeat("w ", streamChunk/2-4) + "W X Y end"},
+		{late, strings.Repeat("w ", streamChunk/2-5) + "an elppa x"},
+	}
+	readers := map[string]func(io.Reader) io.Reader{
+		"whole": func(r io.Reader) io.Reader { return r },
+		"half":  iotest.HalfReader,
+	}
+	for i, tt := range tests {
+		for name, wrap := range readers {
+			var sb strings.Builder
+			if err := Process(wrap(strings.NewReader(tt.in)), &sb, Options{}); err != nil {
+				t.Fatalf("%d/%s: %v", i, name, err)
+			}
+			if got := sb.String(); got != tt.want {
+				t.Errorf("%d/%s: output differs at byte %d", i, name, firstDiff(got, tt.want))
+			}
+		}
+	}
+}
+
+// TestStreamLongLines checks that lines without a safe cut, such as one
+// that opens a parenthesis early or has no space at all, are not held in
+// memory whole.
+func TestStreamLongLines(t *testing.T) {
+	for _, text := range []string{
+		"(note " + strings.Repeat("word ", 3*streamMaxCarry/5),
+		strings.Repeat("é", 3*streamMaxCarry/2),
+	} {
+		var out strings.Builder
+		in := &lagReader{r: strings.NewReader(text), out: &out}
+		if err := Process(in, &out, Options{}); err != nil {
+			t.Fatal(err)
+		}
+		if out.String() != text {
+			t.Errorf("%.10q...: output differs at byte %d", text, firstDiff(out.String(), text))
+		}
+		// The carry and the tokens every pass holds back are bounded.
+		limit := streamMaxCarry + 2*streamChunk + len(DefaultPasses())*streamKeepBytes
+		if in.maxLag > limit {
+			t.Errorf("%.10q...: %d bytes read but not written, want at most %d", text, in.maxLag, limit)
+		}
+	}
+}
+
+// lagReader records how far the output falls behind what was read.
+type lagReader struct {
+	r      io.Reader
+	out    *strings.Builder
+	read   int
+	maxLag int
+}
+
+func (l *lagReader) Read(p []byte) (int, error) {
+	l.maxLag = max(l.maxLag, l.read-l.out.Len())
+	n, err := l.r.Read(p)
+	l.read += n
+	return n, err
+}
+
+func firstDiff(a, b string) int {
+	i := 0
+	for i < len(a) && i < len(b) && a[i] == b[i] {
+		i++
+	}
+	return i
+}
+
+func TestParseNumber(t *testing.T) {
+	tests := []struct {
+		in   string
+		base int
+		want string
+		ok   bool
+	}{
+		{"1E", 16, "30", true},
+		{"0x1e", 16, "30", true},
+		{"-0x1E", 16, "-30", true},
+		{"0b101", 2, "5", true},
+		{"0b101", 16, "45313", true},
+		{"0o17", 8, "15", true},
+		{"FFFFFFFFFFFFFFFFFFFF", 16, "1208925819614629174706175", true},
+		{"0x", 16, "", false},
+		{"--1", 10, "", false},
+		{"+-1", 10, "", false},
+		{"12", 2, "", false},
+		{"", 10, "", false},
+	}
+	for _, tt := range tests {
+		n, err := parseNumber(tt.in, tt.base)
+		if (err == nil) != tt.ok || err == nil && n.String() != tt.want {
+			t.Errorf("parseNumber(%q, %d) = %v, %v", tt.in, tt.base, n, err)
+		}
+	}
+}
+
+func TestRules(t *testing.T) {
+	tests := []struct{ in, want string }{
+		{"ff (hex)", "255"},
+		{"101 (bin)", "5"},
+		{"17 (oct)", "15"},
+		{"255 (dec→hex)", "FF"},
+		{"255 (dec->hex)", "FF"},
+		{"it works (up, 2)", "IT WORKS"},
+		{"LOUD (low)", "loud"},
+		{"élan (cap)", "Élan"},
+		{"well-known (title)", "Well-Known"},
+		{"drawer (rev)", "reward"},
+		{"camelCase (snake)", "camel_Case"},
+		{"Hello (rot)", "Vszzc"},
+		{"shout (UP) (Cap)", "Shout"},
+		{"no (down) rule", "no (down) rule"},
+	}
+	for _, tt := range tests {
+		if got, err := ProcessString(tt.in, Options{}); err != nil || got != tt.want {
+			t.Errorf("%q: got %q, %v, want %q", tt.in, got, err, tt.want)
+		}
+	}
+}
+
+func TestCapitalize(t *testing.T) {
+	tests := []struct{ in, want string }{
+		{"", ""},
+		{"word", "Word"},
+		{"WORD", "Word"},
+		{"élan", "Élan"},
+		{"ǆungla", "ǅungla"},
+		{"ßtraße", "ßtraße"},
+		{"1st", "1st"},
+	}
+	for _, tt := range tests {
+		if got := capitalize(tt.in); got != tt.want {
+			t.Errorf("capitalize(%q) = %q, want %q", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestArticles(t *testing.T) {
+	tests := []struct{ in, want string }{
+		{"a apple", "an apple"},
+		{"A apple", "An apple"},
+		{"a hour", "an hour"},
+		{"a honest man", "an honest man"},
+		{"a house", "a house"},
+		{"a unicorn", "a unicorn"},
+		{"a umbrella", "an umbrella"},
+		{"a European", "a European"},
+		{"a one-off", "a one-off"},
+		{"a FBI agent", "an FBI agent"},
+		{"a UFO", "a UFO"},
+		{"a HTML page", "an HTML page"},
+		{"A APPLE", "AN APPLE"},
+		{"a 8", "an 8"},
+		{"a 11", "an 11"},
+		{"a 18,000", "an 18,000"},
+		{"a 110", "a 110"},
+		{"a 'apple'", "an 'apple'"},
+		{"a élan", "an élan"},
+		{"a", "a"},
+	}
+	for _, tt := range tests {
+		if got, err := ProcessString(tt.in, Options{}); err != nil || got != tt.want {
+			t.Errorf("%q: got %q, %v, want %q", tt.in, got, err, tt.want)
+		}
+	}
+}
+
+func TestTraceEveryPass(t *testing.T) {
+	in := "he said ,' hi ' and a apple (up)\nfirst line\n, second"
+	var steps []string
+	trace := WithTrace(func(s Step) { steps = append(steps, s.String()) })
+	if _, err := ProcessString(in, NewOptions(trace)); err != nil {
+		t.Fatal(err)
+	}
+	want := []string{
+		"line 1: (up) apple → APPLE",
+		"line 1: punctuation: ' ,' → ', '",
+		`line 3: punctuation: '\n, ' → ',\n'`,
+		"line 1: quotes: paired ' at 1:10",
+		"line 1: article: a → an before 'APPLE'",
+	}
+	if !slices.Equal(steps, want) {
+		t.Errorf("got steps\n%s\nwant\n%s", strings.Join(steps, "\n"), strings.Join(want, "\n"))
+	}
+
+	// Streaming re-runs the passes over held back tokens; that must not
+	// repeat a step.
+	steps = nil
+	if err := Process(iotest.OneByteReader(strings.NewReader(in)), io.Discard, NewOptions(trace)); err != nil {
+		t.Fatal(err)
+	}
+	if !slices.Equal(steps, want) {
+		t.Errorf("streamed steps\n%s", strings.Join(steps, "\n"))
+	}
+}
+
+func TestConversionWarnings(t *testing.T) {
+	var warnings []Warning
+	got, err := ProcessString("ok 1E (hex)\nZZ (hex) 12 (bin)", NewOptions(WithWarnings(func(w Warning) {
+		warnings = append(warnings, w)
+	})))
+	if err != nil || got != "ok 30\nZZ 12" {
+		t.Errorf("got %q, %v", got, err)
+	}
+	want := []string{
+		`line 2:1: (hex): "ZZ" is not a hexadecimal number`,
+		`line 2:10: (bin): "12" is not a binary number`,
+	}
+	if len(warnings) != len(want) {
+		t.Fatalf("warnings: %v", warnings)
+	}
+	for i, w := range warnings {
+		if w.String() != want[i] {
+			t.Errorf("warning %d = %q, want %q", i, w, want[i])
+		}
+	}
+}
+
+func TestMalformedModifiers(t *testing.T) {
+	tests := []struct {
+		in, want string
+		warning  string
+	}{
+		{"Prices (low, medium or high) vary", "Prices (low, medium or high) vary", ""},
+		{"see (up, down, left) here", "see (up, down, left) here", ""},
+		{"one (cap, 3 three", "one (cap, 3 three", ""},
+		{"it (up, -2) goes", "it goes", `line 1:4: malformed modifier "(up, -2)": count -2 must be positive`},
+		{"it (low, x) goes", "it goes", `line 1:4: malformed modifier "(low, x)": count "x" is not a number`},
+		{"it (up,) goes", "it goes", `line 1:4: malformed modifier "(up,)": missing count`},
+		{"ab (hex, 2) goes", "ab goes", `line 1:4: malformed modifier "(hex, 2)": (hex) does not take a count`},
+		{"ff (hex)\nx (low, 3)\n", "255\nx\n", ""},
+	}
+	for _, tt := range tests {
+		var warnings []string
+		got, err := ProcessString(tt.in, NewOptions(WithWarnings(func(w Warning) {
+			warnings = append(warnings, w.String())
+		})))
+		if err != nil || got != tt.want {
+			t.Errorf("%q: got %q, %v, want %q", tt.in, got, err, tt.want)
+		}
+		if tt.warning == "" && len(warnings) > 0 || tt.warning != "" && (len(warnings) != 1 || warnings[0] != tt.warning) {
+			t.Errorf("%q: warnings %q, want %q", tt.in, warnings, tt.warning)
+		}
+	}
+}
+
+func TestQuoteWarnings(t *testing.T) {
+	tests := []struct {
+		in, want string
+		warnings []string
+	}{
+		{"he said ' hello and left", "he said ' hello and left", []string{"line 1:9: ' is never closed"}},
+		{"oops\" here", "oops\" here", []string{`line 1:5: closing " has no opening quote`}},
+		{`" outer ' inner " end`, `"outer ' inner" end`, []string{"line 1:9: ' is never closed"}},
+		{`" she said ' hi ' then "`, `"she said 'hi' then"`, nil},
+		{"the students' books", "the students' books", nil},
+		{"' one\ntwo '", "'one\ntwo'", nil},
+	}
+	for _, tt := range tests {
+		var warnings []string
+		got, err := ProcessString(tt.in, NewOptions(WithWarnings(func(w Warning) {
+			warnings = append(warnings, w.String())
+		})))
+		if err != nil || got != tt.want {
+			t.Errorf("%q: got %q, %v, want %q", tt.in, got, err, tt.want)
+		}
+		if !slices.Equal(warnings, tt.warnings) {
+			t.Errorf("%q: warnings %q, want %q", tt.in, warnings, tt.warnings)
+		}
+	}
+
+	if _, err := ProcessString("' open\n", NewOptions(WithStrict())); err == nil {
+		t.Error("strict mode accepted a quote that is never closed")
+	}
+}
+
+func TestPassesInIsolation(t *testing.T) {
+	tests := []struct {
+		passes string
+		in     string
+		want   string
+	}{
+		{"modifiers", "hello ,world (up) a apple ' hi '", "hello ,WORLD a apple ' hi '"},
+		{"punctuation", "hello ,world (up) a apple ' hi '", "hello, world (up) a apple ' hi '"},
+		{"quotes", "hello ,world (up) a apple ' hi '", "hello ,world (up) a apple 'hi'"},
+		{"articles", "hello ,world (up) a apple ' hi '", "hello ,world (up) an apple ' hi '"},
+		{"punctuation,modifiers", "go (up) !", "GO!"},
+	}
+	for _, tt := range tests {
+		p, err := ParsePipeline(tt.passes)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got, err := ProcessString(tt.in, Options{Passes: p}); err != nil || got != tt.want {
+			t.Errorf("%s: got %q, %v, want %q", tt.passes, got, err, tt.want)
+		}
+	}
+}
+
+func TestParsePipelineErrors(t *testing.T) {
+	for _, list := range []string{"", " , ", "modifiers,foo", "quotes,quotes"} {
+		if _, err := ParsePipeline(list); err == nil {
+			t.Errorf("ParsePipeline(%q) succeeded", list)
+		}
+	}
+}
+
+func TestPunctuationMatrix(t *testing.T) {
+	groups := []string{".", ",", "!", "?", ":", ";", "...", "!?", "?!", "!!"}
+	layouts := []struct{ in, want string }{
+		{"word%s next", "word%s next"},
+		{"word %s next", "word%s next"},
+		{"word%snext", "word%s next"},
+		{"word %snext", "word%s next"},
+		{"word  %s   next", "word%s next"},
+		{"word\t%s\tnext", "word%s next"},
+		{"word %s\nnext", "word%s\nnext"},
+		{"word %s", "word%s"},
+		{"%s next", "%s next"},
+		{"word%s\"next\"", "word%s \"next\""},
+		{"word %s'next'", "word%s 'next'"},
+		{"word%s  'next'", "word%s 'next'"},
+		{"'word%s' next", "'word%s' next"},
+		{"word %s' next '", "word%s 'next'"},
+		{"\"word %s\" next", "\"word%s\" next"},
+		{"word\n%s next", "word%s\nnext"},
+		{"word  \n  %s next", "word%s\n  next"},
+		{"word %s   \nnext", "word%s\nnext"},
+		{"word%s\t\r\nnext", "word%s\r\nnext"},
+	}
+	for _, g := range groups {
+		for _, l := range layouts {
+			in := strings.ReplaceAll(l.in, "%s", g)
+			want := strings.ReplaceAll(l.want, "%s", g)
+			if got, err := ProcessString(in, Options{}); err != nil || got != want {
+				t.Errorf("%q: got %q, %v, want %q", in, got, err, want)
+			}
+		}
+	}
+}
+
+func TestPunctuationInsideWords(t *testing.T) {
+	tests := []struct{ in, want string }{
+		{"3.14", "3.14"},
+		{"1,000,000", "1,000,000"},
+		{"10:30", "10:30"},
+		{"e.g. this", "e.g. this"},
+		{"U.S.A.", "U.S.A."},
+		{"don't", "don't"},
+		{"page,5", "page, 5"},
+		{"5,page", "5, page"},
+		{"end.Start", "end. Start"},
+		{"3 ,5", "3, 5"},
+	}
+	for _, tt := range tests {
+		if got, err := ProcessString(tt.in, Options{}); err != nil || got != tt.want {
+			t.Errorf("%q: got %q, %v, want %q", tt.in, got, err, tt.want)
+		}
+	}
+}
+
+// FuzzProcess checks that no input makes the engine panic and that
+// streaming a text in small reads gives the same result as reading it at
+// once.
+func FuzzProcess(f *testing.F) {
+	inputs, _ := filepath.Glob(filepath.Join("testdata", "*.txt"))
+	for _, in := range inputs {
+		if text, err := os.ReadFile(in); err == nil {
+			f.Add(string(text))
+		}
+	}
+	f.Add("word (up,\xe9")
+	f.Add("it (cap, 2) ' quoted ' ,a apple (hex)")
+	f.Fuzz(func(t *testing.T, text string) {
+		want, err := ProcessString(text, Options{})
+		if err != nil {
+			t.Fatal(err)
+		}
+		var sb strings.Builder
+		if err := Process(iotest.OneByteReader(strings.NewReader(text)), &sb, Options{}); err != nil {
+			t.Fatal(err)
+		}
+		if sb.String() != want {
+			t.Errorf("streamed output differs:\ngot:  %q\nwant: %q", sb.String(), want)
+		}
+	})
+}
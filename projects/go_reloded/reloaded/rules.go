@@ -0,0 +1,123 @@
+package reloaded
+
+import "strings"
+
+// rule is a modifier such as "(up)". arity is the number of arguments it
+// accepts: rules with arity 1 may also be written "(name, n)" to apply
+// to the n previous words. apply returns an error when the word cannot be
+// transformed, together with the word unchanged.
+type rule struct {
+	name  string
+	arity int
+	apply func(word string) (string, error)
+}
+
+var rules = map[string]rule{}
+
+// registerRule makes r available as a modifier. Registering a name twice
+// replaces the earlier rule.
+func registerRule(r rule) {
+	rules[r.name] = r
+}
+
+func lookupRule(name string) (rule, bool) {
+	r, ok := rules[name]
+	return r, ok
+}
+
+func init() {
+	registerRule(rule{name: "hex", arity: 0, apply: convertBase(16, 10)})
+	registerRule(rule{name: "bin", arity: 0, apply: convertBase(2, 10)})
+	registerRule(rule{name: "oct", arity: 0, apply: convertBase(8, 10)})
+	registerRule(rule{name: "dec→hex", arity: 0, apply: convertBase(10, 16)})
+	registerRule(rule{name: "dec->hex", arity: 0, apply: convertBase(10, 16)})
+	registerRule(rule{name: "up", arity: 1, apply: infallible(strings.ToUpper)})
+	registerRule(rule{name: "low", arity: 1, apply: infallible(strings.ToLower)})
+	registerRule(rule{name: "cap", arity: 1, apply: infallible(capitalize)})
+	registerRule(rule{name: "title", arity: 1, apply: infallible(title)})
+	registerRule(rule{name: "rev", arity: 1, apply: infallible(reverse)})
+	registerRule(rule{name: "snake", arity: 1, apply: infallible(camelToSnakeCase)})
+	registerRule(rule{name: "rot", arity: 1, apply: infallible(rot14)})
+}
+
+// infallible adapts a transformation that works on any word to rule.apply.
+func infallible(f func(string) string) func(string) (string, error) {
+	return func(s string) (string, error) {
+		return f(s), nil
+	}
+}
+
+// title capitalizes every part of a hyphenated word: "well-known" becomes
+// "Well-Known".
+func title(s string) string {
+	parts := strings.Split(s, "-")
+	for i, p := range parts {
+		parts[i] = capitalize(p)
+	}
+	return strings.Join(parts, "-")
+}
+
+func reverse(s string) string {
+	runes := []rune(s)
+	for i, j := 0, len(runes)-1; i < j; i, j = i+1, j-1 {
+		runes[i], runes[j] = runes[j], runes[i]
+	}
+	return string(runes)
+}
+
+// camelToSnakeCase and rot14 are copied from the piscine solutions, which
+// live in a separate module that go_reloded cannot import.
+
+func camelToSnakeCase(s string) string {
+	result := ""
+	if len(s) == 0 {
+		return ""
+	}
+
+	if !isCamelCase(s) {
+		return s
+	}
+
+	for i, ch := range s {
+		if ch >= 'A' && ch <= 'Z' {
+			if i > 0 {
+				result += "_"
+			}
+			result += string(ch)
+		} else {
+			result += string(ch)
+		}
+	}
+	return result
+}
+
+func isCamelCase(s string) bool {
+	for i := 0; i < len(s); i++ {
+		ch := s[i]
+		if i == len(s)-1 && ch >= 'A' && ch <= 'Z' {
+			return false
+		} else if i < len(s) && (ch >= 'A' && ch <= 'Z') && (s[i+1] >= 'A' && s[i+1] <= 'Z') {
+			return false
+		} else if !((ch >= 'a' && ch <= 'z') || (ch >= 'A' && ch <= 'Z')) {
+			return false
+		}
+	}
+	return true
+}
+
+func rot14(s string) string {
+	result := ""
+	for _, ch := range s {
+		if (ch >= 'a' && ch <= 'z') || (ch >= 'A' && ch <= 'Z') {
+			rot14 := ch + 14
+			if (rot14 > 'z' && (ch >= 'a' && ch <= 'z')) || (rot14 > 'Z' && (ch >= 'A' && ch <= 'Z')) {
+				rot14 -= 26
+			}
+			result += string(rot14)
+		} else {
+			result += string(ch)
+		}
+	}
+
+	return result
+}
@@ -0,0 +1,160 @@
+package reloaded
+
+import (
+	"io"
+	"slices"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+const (
+	streamChunk = 64 * 1024
+	// streamKeepWords is how many processed words are held back before
+	// being written, so a counted modifier in the next chunk can still
+	// reach them. Counts larger than this stop at the held back words.
+	streamKeepWords = 1024
+	// streamKeepBytes bounds the tokens every pass holds back, so a count
+	// reaches fewer words when they are very long.
+	streamKeepBytes = 256 << 10
+	// streamMaxCarry bounds the text waiting for a safe cut. A line
+	// longer than this without one is cut anyway, which may split a word
+	// or a modifier.
+	streamMaxCarry = 1 << 20
+)
+
+// processStream transforms r into w in bounded memory. Input is read in
+// chunks and only tokenized up to a safe cut, so no token is split. Every
+// pass of the pipeline keeps its output back until streamKeepWords newer
+// words exist and only then hands it to the next pass, so a pass never
+// sees a token that a later pass has already changed. The open quotes
+// live in ctx.
+func processStream(r io.Reader, w io.Writer, ctx *Context) error {
+	pipeline := ctx.opts.Passes
+	if pipeline == nil {
+		pipeline, _ = NewPipeline()
+	}
+	stages := make([]stage, len(pipeline))
+	for i, pass := range pipeline {
+		stages[i].pass = pass
+	}
+	var carry string
+	pos := position{1, 1}
+	chunk := make([]byte, streamChunk)
+	for {
+		n, readErr := r.Read(chunk)
+		if readErr != nil && readErr != io.EOF {
+			return readErr
+		}
+		eof := readErr == io.EOF
+		text := carry + string(chunk[:n])
+		cut := len(text)
+		if !eof {
+			cut = safeCut(text)
+			if len(text)-cut > streamMaxCarry {
+				cut = forceCut(text)
+			}
+		}
+		carry = text[cut:]
+		tokens := pos.tokenize(text[:cut])
+		for i := range stages {
+			tokens = stages[i].run(tokens, ctx, eof)
+		}
+		if eof {
+			ctx.finishQuotes()
+		}
+		if err := ctx.err(); err != nil {
+			return err
+		}
+		if _, err := io.WriteString(w, render(tokens)); err != nil {
+			return err
+		}
+		if eof {
+			return nil
+		}
+	}
+}
+
+// stage is one pass of a streamed pipeline with the tokens it keeps back.
+type stage struct {
+	pass    Pass
+	pending []Token
+}
+
+// run passes tokens through the pass together with the tokens kept back
+// from earlier calls and returns those that are done, which is all of
+// them at the end of the input.
+func (s *stage) run(tokens []Token, ctx *Context, eof bool) []Token {
+	s.pending = s.pass.Run(append(s.pending, tokens...), ctx)
+	if eof {
+		done := s.pending
+		s.pending = nil
+		return done
+	}
+	split := keepFrom(s.pending, streamKeepWords)
+	done := s.pending[:split]
+	s.pending = slices.Clone(s.pending[split:])
+	return done
+}
+
+// safeCut returns how much of text can be tokenized without knowing what
+// comes next. The cut is placed right after a run of whitespace that is
+// followed by more text, so the last word, which may be incomplete, waits
+// for the next chunk and every token before the cut still sees the token
+// that follows it. A parenthesis left open on the last line may still
+// become a modifier, so the cut also moves before it.
+func safeCut(text string) int {
+	cut := lastWordStart(text)
+	if open := strings.LastIndexByte(text[:cut], '('); open >= 0 && !strings.ContainsAny(text[open:cut], ")\n") {
+		cut = lastWordStart(text[:open])
+	}
+	return cut
+}
+
+// forceCut returns where to cut text that has no safe cut: after its last
+// whitespace, or else before an incomplete rune at its end.
+func forceCut(text string) int {
+	if cut := lastWordStart(text); cut > 0 {
+		return cut
+	}
+	for i := len(text) - 1; i >= max(len(text)-utf8.UTFMax, 0); i-- {
+		if utf8.RuneStart(text[i]) {
+			if !utf8.FullRuneInString(text[i:]) {
+				return i
+			}
+			break
+		}
+	}
+	return len(text)
+}
+
+// lastWordStart returns where the last run of non-space text in s starts,
+// ignoring trailing whitespace, or 0 if there is none before it.
+func lastWordStart(s string) int {
+	s = strings.TrimRightFunc(s, unicode.IsSpace)
+	i := strings.LastIndexFunc(s, unicode.IsSpace)
+	if i < 0 {
+		return 0
+	}
+	_, size := utf8.DecodeRuneInString(s[i:])
+	return i + size
+}
+
+// keepFrom returns the index of the keep-th word from the end of tokens,
+// or 0 when there are fewer words than that. The tokens from the index on
+// never hold more than streamKeepBytes bytes.
+func keepFrom(tokens []Token, keep int) int {
+	size := 0
+	for i := len(tokens) - 1; i >= 0; i-- {
+		if size += len(tokens[i].Text); size > streamKeepBytes {
+			return i + 1
+		}
+		if tokens[i].Kind == Word {
+			keep--
+			if keep == 0 {
+				return i
+			}
+		}
+	}
+	return 0
+}
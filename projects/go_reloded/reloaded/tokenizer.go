@@ -0,0 +1,146 @@
+package reloaded
+
+import (
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// TokenKind is what a Token is, which decides the passes that touch it.
+type TokenKind int
+
+const (
+	Word     TokenKind = iota // anything that is not one of the others
+	Punct                     // a run of . , ! ? : ; outside a word
+	Quote                     // a single " or a ' that is not inside a word
+	Modifier                  // a modifier such as "(up)" or "(low, 2)", well formed or not
+	Space                     // a run of whitespace, line breaks included
+)
+
+// Token is a piece of the input text. Joining the Text of every token
+// gives back the text exactly, so whitespace and newlines survive the
+// transformation passes untouched.
+type Token struct {
+	Kind TokenKind
+	Text string
+	Line int
+	Col  int
+	// role is set on a Quote once fixQuotes has decided what it is, so
+	// running the pass again over already processed tokens leaves it alone.
+	role quoteRole
+}
+
+// position is where the next token starts.
+type position struct {
+	line, col int
+}
+
+func tokenize(text string) []Token {
+	p := position{1, 1}
+	return p.tokenize(text)
+}
+
+// tokenize splits text into tokens numbered from p and advances p past
+// the end of text.
+func (p *position) tokenize(text string) []Token {
+	var tokens []Token
+	for i := 0; i < len(text); {
+		r, size := utf8.DecodeRuneInString(text[i:])
+		var kind TokenKind
+		n := size
+		switch {
+		case unicode.IsSpace(r):
+			kind = Space
+			n = spanFunc(text[i:], unicode.IsSpace)
+		case r == '(' && modifierLen(text[i:]) > 0:
+			kind = Modifier
+			n = modifierLen(text[i:])
+		case r == '"' || r == '\'' && !inWord(text, i, size):
+			kind = Quote
+		case isPunctuation(r) && !inWord(text, i, size):
+			kind = Punct
+			n = spanFunc(text[i:], isPunctuation)
+		default:
+			kind = Word
+			n = wordLen(text, i)
+		}
+		tokens = append(tokens, Token{Kind: kind, Text: text[i : i+n], Line: p.line, Col: p.col})
+		for _, r := range text[i : i+n] {
+			if r == '\n' {
+				p.line, p.col = p.line+1, 1
+			} else {
+				p.col++
+			}
+		}
+		i += n
+	}
+	return tokens
+}
+
+// render joins tokens back into text.
+func render(tokens []Token) string {
+	var sb strings.Builder
+	for _, t := range tokens {
+		sb.WriteString(t.Text)
+	}
+	return sb.String()
+}
+
+func spanFunc(s string, f func(rune) bool) int {
+	// Ranging over s steps over an invalid byte as one RuneError of
+	// width 1, so i is always a valid cut.
+	for i, r := range s {
+		if !f(r) {
+			return i
+		}
+	}
+	return len(s)
+}
+
+func isPunctuation(r rune) bool {
+	return r == '.' || r == '!' || r == '?' || r == ':' || r == ';' || r == ','
+}
+
+func isAlnum(r rune) bool {
+	return unicode.IsLetter(r) || unicode.IsDigit(r)
+}
+
+// inWord reports whether the apostrophe or punctuation mark at
+// text[i:i+size] belongs to the word around it. An apostrophe does
+// between two letters or digits, as in "don't". Punctuation does between
+// two digits, as in "3.14" or "1,000", and so does the dot of an
+// abbreviation such as "e.g.", which follows a single letter and comes
+// before another one. Any other mark splits the word.
+func inWord(text string, i, size int) bool {
+	before, n := utf8.DecodeLastRuneInString(text[:i])
+	after, _ := utf8.DecodeRuneInString(text[i+size:])
+	switch text[i] {
+	case '\'':
+		return isAlnum(before) && isAlnum(after)
+	case '.':
+		if unicode.IsLetter(before) && unicode.IsLetter(after) {
+			prev, _ := utf8.DecodeLastRuneInString(text[:i-n])
+			return !unicode.IsLetter(prev)
+		}
+	}
+	return unicode.IsDigit(before) && unicode.IsDigit(after)
+}
+
+// wordLen returns the length of the word starting at text[i:].
+func wordLen(text string, i int) int {
+	j := i
+	for j < len(text) {
+		r, size := utf8.DecodeRuneInString(text[j:])
+		if j > i && (unicode.IsSpace(r) || r == '(' && modifierLen(text[j:]) > 0) {
+			break
+		}
+		if j > i && r == '"' {
+			break
+		}
+		if j > i && (r == '\'' || isPunctuation(r)) && !inWord(text, j, size) {
+			break
+		}
+		j += size
+	}
+	return j - i
+}
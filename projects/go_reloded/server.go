@@ -0,0 +1,141 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"html/template"
+	"log"
+	"net/http"
+	"os"
+	"slices"
+
+	"go_reloded/reloaded"
+)
+
+// maxTextSize limits how much text one request may send.
+const maxTextSize = 1 << 20
+
+// Transformation is the result of running the pipeline over a text.
+type Transformation struct {
+	Result   string             `json:"result"`
+	Trace    []reloaded.Step    `json:"trace"`
+	Warnings []reloaded.Warning `json:"warnings"`
+}
+
+// PassOption is a pass the playground form can switch on or off.
+type PassOption struct {
+	Name    string
+	Checked bool
+}
+
+type PlaygroundData struct {
+	Text   string
+	Passes []PassOption
+	Error  string
+	Transformation
+}
+
+func serveMain(args []string) {
+	fs := flag.NewFlagSet("serve", flag.ExitOnError)
+	addr := fs.String("addr", ":8080", "address to listen on")
+	fs.Parse(args)
+
+	http.HandleFunc("/", playgroundHandler)
+	http.HandleFunc("/transform", transformHandler)
+	fmt.Fprintf(os.Stderr, "go_reloded playground listening on %s\n", *addr)
+	log.Fatal(http.ListenAndServe(*addr, nil))
+}
+
+// transform runs the passes named in passes, or the default pipeline when
+// there are none, over text and records what every rule did.
+func transform(text string, passes []string) (Transformation, error) {
+	pipeline, err := reloaded.NewPipeline(passes...)
+	if err != nil {
+		return Transformation{}, err
+	}
+	t := Transformation{Trace: []reloaded.Step{}, Warnings: []reloaded.Warning{}}
+	t.Result, err = reloaded.ProcessString(text, reloaded.NewOptions(
+		reloaded.WithPipeline(pipeline),
+		reloaded.WithTrace(func(s reloaded.Step) { t.Trace = append(t.Trace, s) }),
+		reloaded.WithWarnings(func(w reloaded.Warning) { t.Warnings = append(t.Warnings, w) }),
+	))
+	sortSteps(t.Trace)
+	return t, err
+}
+
+func playgroundHandler(w http.ResponseWriter, r *http.Request) {
+	if r.URL.Path != "/" {
+		http.Error(w, "Page Not Found", 404)
+		return
+	}
+	if r.Method != "GET" && r.Method != "POST" {
+		http.Error(w, "Method Not Allowed", 405)
+		return
+	}
+
+	data := PlaygroundData{}
+	checked := reloaded.DefaultPasses()
+	if r.Method == "POST" {
+		r.Body = http.MaxBytesReader(w, r.Body, maxTextSize)
+		if err := r.ParseForm(); err != nil {
+			http.Error(w, "Bad Request", 400)
+			return
+		}
+		data.Text = r.PostForm.Get("text")
+		checked = r.PostForm["pass"]
+		if len(checked) == 0 {
+			data.Error = "select at least one pass"
+		} else if t, err := transform(data.Text, checked); err != nil {
+			data.Error = err.Error()
+		} else {
+			data.Transformation = t
+		}
+	}
+	for _, name := range reloaded.DefaultPasses() {
+		data.Passes = append(data.Passes, PassOption{name, slices.Contains(checked, name)})
+	}
+
+	tmpl, err := template.ParseFiles("./templates/index.html")
+	if err != nil {
+		http.Error(w, "Internal Server Error", 500)
+		return
+	}
+
+	if err := tmpl.Execute(w, data); err != nil {
+		log.Printf("template execute error: %v", err)
+	}
+}
+
+// transformHandler serves POST /transform. The request body is
+//
+//	{"text": "it (cap) works", "passes": ["modifiers"]}
+//
+// where passes is optional, and the answer is a Transformation.
+func transformHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != "POST" {
+		http.Error(w, "Method Not Allowed", 405)
+		return
+	}
+
+	var req struct {
+		Text   string   `json:"text"`
+		Passes []string `json:"passes"`
+	}
+	dec := json.NewDecoder(http.MaxBytesReader(w, r.Body, maxTextSize))
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(&req); err != nil {
+		http.Error(w, "Bad Request: "+err.Error(), 400)
+		return
+	}
+
+	t, err := transform(req.Text, req.Passes)
+	if err != nil {
+		http.Error(w, "Bad Request: "+err.Error(), 400)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(t); err != nil {
+		log.Printf("json encode error: %v", err)
+	}
+}
@@ -0,0 +1,168 @@
+package main
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"math/rand"
+	"os"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// thresholds are the maximum instruction counts allowed by the project
+// for each benchmarked input size.
+var thresholds = map[int]int{
+	3:   3,
+	5:   12,
+	100: 700,
+	500: 5500,
+}
+
+type benchResult struct {
+	Strategy string  `json:"strategy"`
+	Size     int     `json:"size"`
+	Runs     int     `json:"runs"`
+	Min      int     `json:"min"`
+	Mean     float64 `json:"mean"`
+	Max      int     `json:"max"`
+	P95      int     `json:"p95"`
+	Invalid  int     `json:"invalid"`
+	Limit    int     `json:"limit"`
+	PassRate float64 `json:"pass_rate"`
+	Wins     int     `json:"wins"`
+}
+
+// benchMain grades the solver on random permutations. With -strategy=all
+// every registered strategy runs on the same inputs and the report counts
+// how often each one produced the shortest sequence:
+//
+//	push-swap bench [-n runs] [-seed s] [-sizes 3,5,100,500] [-strategy name|all] [-format text|csv|json]
+func benchMain(args []string) {
+	fs := flag.NewFlagSet("bench", flag.ExitOnError)
+	runs := fs.Int("n", 100, "permutations per size")
+	seed := fs.Int64("seed", 1, "random seed")
+	sizes := fs.String("sizes", "3,5,100,500", "comma separated input sizes")
+	format := fs.String("format", "text", "output format: text, csv or json")
+	strategy := fs.String("strategy", "auto", "strategy to grade, or all to compare them")
+	fs.Parse(args)
+
+	sts := strategies
+	if *strategy != "all" {
+		st, ok := lookupStrategy(*strategy)
+		if !ok {
+			fmt.Fprintf(os.Stderr, "unknown strategy %q\n", *strategy)
+			os.Exit(1)
+		}
+		sts = []Strategy{st}
+	}
+
+	var results []benchResult
+	rng := rand.New(rand.NewSource(*seed))
+	for _, f := range strings.Split(*sizes, ",") {
+		n, err := strconv.Atoi(strings.TrimSpace(f))
+		if err != nil || n < 0 {
+			fmt.Fprintf(os.Stderr, "invalid size %q\n", f)
+			os.Exit(1)
+		}
+		results = append(results, benchSize(rng, n, *runs, sts)...)
+	}
+
+	if err := writeBench(results, *format); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+}
+
+// benchSize solves runs random permutations of n elements with each
+// strategy and verifies every result with the checker engine.
+func benchSize(rng *rand.Rand, n, runs int, sts []Strategy) []benchResult {
+	results := make([]benchResult, len(sts))
+	counts := make([][]int, len(sts))
+	passed := make([]int, len(sts))
+	for i, st := range sts {
+		results[i] = benchResult{Strategy: st.Name(), Size: n, Runs: runs, Limit: thresholds[n]}
+	}
+	for range runs {
+		nums := rng.Perm(n)
+		lens := make([]int, len(sts))
+		shortest := -1
+		for i, st := range sts {
+			ops := solveWith(st, nums)
+			if !sortsInput(nums, ops) {
+				results[i].Invalid++
+				lens[i] = -1
+				continue
+			}
+			lens[i] = len(ops)
+			counts[i] = append(counts[i], len(ops))
+			if results[i].Limit == 0 || len(ops) <= results[i].Limit {
+				passed[i]++
+			}
+			if shortest < 0 || len(ops) < shortest {
+				shortest = len(ops)
+			}
+		}
+		for i, l := range lens {
+			if l >= 0 && l == shortest {
+				results[i].Wins++
+			}
+		}
+	}
+	for i := range results {
+		summarize(&results[i], counts[i], passed[i])
+	}
+	return results
+}
+
+func summarize(res *benchResult, counts []int, passed int) {
+	if res.Runs > 0 {
+		res.PassRate = float64(passed) / float64(res.Runs)
+	}
+	if len(counts) == 0 {
+		return
+	}
+	slices.Sort(counts)
+	sum := 0
+	for _, c := range counts {
+		sum += c
+	}
+	res.Min = counts[0]
+	res.Max = counts[len(counts)-1]
+	res.Mean = float64(sum) / float64(len(counts))
+	res.P95 = counts[(len(counts)*95+99)/100-1]
+}
+
+func writeBench(results []benchResult, format string) error {
+	switch format {
+	case "text":
+		fmt.Printf("%-8s %6s %6s %6s %9s %6s %6s %8s %6s %6s %6s\n",
+			"strategy", "size", "runs", "min", "mean", "max", "p95", "invalid", "limit", "pass", "wins")
+		for _, r := range results {
+			fmt.Printf("%-8s %6d %6d %6d %9.1f %6d %6d %8d %6d %5.1f%% %6d\n",
+				r.Strategy, r.Size, r.Runs, r.Min, r.Mean, r.Max, r.P95, r.Invalid, r.Limit, r.PassRate*100, r.Wins)
+		}
+	case "csv":
+		w := csv.NewWriter(os.Stdout)
+		w.Write([]string{"strategy", "size", "runs", "min", "mean", "max", "p95", "invalid", "limit", "pass_rate", "wins"})
+		for _, r := range results {
+			w.Write([]string{
+				r.Strategy, strconv.Itoa(r.Size), strconv.Itoa(r.Runs), strconv.Itoa(r.Min),
+				strconv.FormatFloat(r.Mean, 'f', 2, 64), strconv.Itoa(r.Max), strconv.Itoa(r.P95),
+				strconv.Itoa(r.Invalid), strconv.Itoa(r.Limit), strconv.FormatFloat(r.PassRate, 'f', 4, 64),
+				strconv.Itoa(r.Wins),
+			})
+		}
+		w.Flush()
+		return w.Error()
+	case "json":
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		return enc.Encode(results)
+	default:
+		return fmt.Errorf("unknown format %q", format)
+	}
+	return nil
+}
@@ -0,0 +1,99 @@
+package main
+
+import (
+	"cmp"
+	"fmt"
+	"io"
+)
+
+// diagnosis describes what a checked instruction sequence did.
+type diagnosis struct {
+	ok         bool
+	finalA     []int
+	finalB     []int
+	firstNoop  int // 1-based position of the first no-op instruction, 0 if none
+	noop       Instruction
+	longestRun int // longest ascending run of a seen at any step
+	runStep    int // instruction count after which longestRun was reached
+	misplaced  int // elements not at their sorted position in a
+	total      int
+}
+
+// isNoop reports whether in would leave the stacks unchanged.
+func isNoop[T cmp.Ordered](in Instruction, a, b *Stack[T]) bool {
+	switch in {
+	case PA:
+		return b.isEmpty()
+	case PB:
+		return a.isEmpty()
+	case SA, RA, RRA:
+		return a.size() < 2
+	case SB, RB, RRB:
+		return b.size() < 2
+	}
+	return a.size() < 2 && b.size() < 2
+}
+
+// sortedRun returns the length of the longest run of consecutive
+// ascending elements in a.
+func sortedRun(a *Stack[int]) int {
+	if a.isEmpty() {
+		return 0
+	}
+	best, cur := 1, 1
+	for i := 1; i < a.size(); i++ {
+		if a.at(i-1) < a.at(i) {
+			cur++
+		} else {
+			cur = 1
+		}
+		best = max(best, cur)
+	}
+	return best
+}
+
+// diagnose replays ops on nums and collects the -explain report.
+func diagnose(nums []int, ops []Instruction) diagnosis {
+	d := diagnosis{total: len(nums)}
+	a, b := newStack(nums), newStack[int](nil)
+	d.longestRun = sortedRun(a)
+	for i, op := range ops {
+		if d.firstNoop == 0 && isNoop(op, a, b) {
+			d.firstNoop, d.noop = i+1, op
+		}
+		apply(op, a, b)
+		if run := sortedRun(a); run > d.longestRun {
+			d.longestRun, d.runStep = run, i+1
+		}
+	}
+	d.ok = isSorted(a) && b.isEmpty()
+	d.finalA, d.finalB = a.values(), b.values()
+	rank := newRanking(nums)
+	for i, v := range d.finalA {
+		if rank.rank(v) != i {
+			d.misplaced++
+		}
+	}
+	d.misplaced += len(d.finalB)
+	return d
+}
+
+func (d diagnosis) write(w io.Writer) {
+	switch {
+	case d.ok:
+		fmt.Fprintln(w, "OK")
+	case !isSorted(newStack(d.finalA)):
+		fmt.Fprintln(w, "KO: mis-sorted, a is not in ascending order")
+	default:
+		fmt.Fprintf(w, "KO: unfinished, a is sorted but b still holds %d elements\n", len(d.finalB))
+	}
+	if d.firstNoop > 0 {
+		fmt.Fprintf(w, "first no-op: instruction %d (%s)\n", d.firstNoop, d.noop)
+	} else {
+		fmt.Fprintln(w, "first no-op: none")
+	}
+	fmt.Fprintf(w, "a: %v\n", d.finalA)
+	fmt.Fprintf(w, "b: %v\n", d.finalB)
+	fmt.Fprintf(w, "longest sorted run: %d of %d (after instruction %d)\n", d.longestRun, d.total, d.runStep)
+	fmt.Fprintf(w, "misplaced elements: %d\n", d.misplaced)
+}
@@ -1,67 +1,135 @@
 package main
 
-func pa(a, b *Stack) {
+import (
+	"cmp"
+	"fmt"
+	"strconv"
+)
+
+func pa[T cmp.Ordered](a, b *Stack[T]) {
 	top, ok := b.pop()
 	if ok {
 		a.push(top)
 	}
 }
-func pb(a, b *Stack) {
+func pb[T cmp.Ordered](a, b *Stack[T]) {
 	top, ok := a.pop()
 	if ok {
 		b.push(top)
 	}
 }
-func sa(a *Stack) {
-	if len(a.data) >= 2 {
-		n := len(a.data)
-		a.data[n-1], a.data[n-2] = a.data[n-2], a.data[n-1]
-	}
+func sa[T cmp.Ordered](a *Stack[T]) {
+	a.swap()
 }
-func sb(b *Stack) {
-	if len(b.data) >= 2 {
-		n := len(b.data)
-		b.data[n-1], b.data[n-2] = b.data[n-2], b.data[n-1]
-	}
+func sb[T cmp.Ordered](b *Stack[T]) {
+	b.swap()
 }
-func ss(a, b *Stack) {
+func ss[T cmp.Ordered](a, b *Stack[T]) {
 	sa(a)
 	sb(b)
 }
 
-func ra(a *Stack) {
-	if len(a.data) > 1 {
-		top := a.data[len(a.data)-1]
-		a.data = a.data[:len(a.data)-1]
-		a.data = append([]int{top}, a.data...)
-	}
+func ra[T cmp.Ordered](a *Stack[T]) {
+	a.rotate()
 }
-func rb(b *Stack) {
-	if len(b.data) > 1 {
-		top := b.data[len(b.data)-1]
-		b.data = b.data[:len(b.data)-1]
-		b.data = append([]int{top}, b.data...)
-	}
+func rb[T cmp.Ordered](b *Stack[T]) {
+	b.rotate()
 }
-func rr(a, b *Stack) {
+func rr[T cmp.Ordered](a, b *Stack[T]) {
 	ra(a)
 	rb(b)
 }
-func rra(a *Stack) {
-	if len(a.data) > 0 {
-		elem := a.data[0]
-		a.data = a.data[1:]
-		a.data = append(a.data, elem)
-	}
+func rra[T cmp.Ordered](a *Stack[T]) {
+	a.reverseRotate()
 }
-func rrb(b *Stack) {
-	if len(b.data) > 0 {
-		elem := b.data[0]
-		b.data = b.data[1:]
-		b.data = append(b.data, elem)
-	}
+func rrb[T cmp.Ordered](b *Stack[T]) {
+	b.reverseRotate()
 }
-func rrr(a, b *Stack) {
+func rrr[T cmp.Ordered](a, b *Stack[T]) {
 	rra(a)
 	rrb(b)
 }
+
+// Instruction is one of the 11 push-swap operations.
+type Instruction uint8
+
+const (
+	PA Instruction = iota
+	PB
+	SA
+	SB
+	SS
+	RA
+	RB
+	RR
+	RRA
+	RRB
+	RRR
+)
+
+var instructionNames = [...]string{"pa", "pb", "sa", "sb", "ss", "ra", "rb", "rr", "rra", "rrb", "rrr"}
+
+// inverses maps each instruction to the one that undoes it. pa and pb are
+// only inverses when the first push actually moved an element.
+var inverses = map[Instruction]Instruction{
+	PA: PB, PB: PA,
+	SA: SA, SB: SB, SS: SS,
+	RA: RRA, RRA: RA,
+	RB: RRB, RRB: RB,
+	RR: RRR, RRR: RR,
+}
+
+func (in Instruction) String() string {
+	if int(in) < len(instructionNames) {
+		return instructionNames[in]
+	}
+	return "Instruction(" + strconv.Itoa(int(in)) + ")"
+}
+
+func (in Instruction) inverse() Instruction {
+	return inverses[in]
+}
+
+// apply runs in on the stacks.
+func apply[T cmp.Ordered](in Instruction, a, b *Stack[T]) {
+	switch in {
+	case PA:
+		pa(a, b)
+	case PB:
+		pb(a, b)
+	case SA:
+		sa(a)
+	case SB:
+		sb(b)
+	case SS:
+		ss(a, b)
+	case RA:
+		ra(a)
+	case RB:
+		rb(b)
+	case RR:
+		rr(a, b)
+	case RRA:
+		rra(a)
+	case RRB:
+		rrb(b)
+	case RRR:
+		rrr(a, b)
+	}
+}
+
+func parseInstruction(s string) (Instruction, error) {
+	for i, name := range instructionNames {
+		if s == name {
+			return Instruction(i), nil
+		}
+	}
+	return 0, fmt.Errorf("unknown instruction %q", s)
+}
+
+// execute applies ins in order to the stacks.
+func execute[T cmp.Ordered](a, b *Stack[T], ins []Instruction) {
+	for _, in := range ins {
+		apply(in, a, b)
+	}
+}
@@ -1 +1,70 @@
 package main
+
+import (
+	"bufio"
+	"cmp"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+)
+
+func checkerMain() {
+	fs := flag.NewFlagSet("checker", flag.ExitOnError)
+	explain := fs.Bool("explain", false, "explain why the sequence does or does not sort the input")
+	flags, numbers := splitFlags(fs, os.Args[1:])
+	fs.Parse(flags)
+
+	nums, err := parseArgs(numbers)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "Error")
+		os.Exit(1)
+	}
+	if len(nums) == 0 {
+		return
+	}
+	ops, err := readInstructions(os.Stdin)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "Error")
+		os.Exit(1)
+	}
+	if *explain {
+		r := diagnose(nums, ops)
+		r.write(os.Stdout)
+		return
+	}
+	a, b := newStack(nums), newStack[int](nil)
+	execute(a, b, ops)
+	if isSorted(a) && b.isEmpty() {
+		fmt.Println("OK")
+	} else {
+		fmt.Println("KO")
+	}
+}
+
+// readInstructions reads one instruction per line until EOF.
+func readInstructions(r io.Reader) ([]Instruction, error) {
+	var ops []Instruction
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		line := scanner.Text()
+		if line == "" {
+			continue
+		}
+		op, err := parseInstruction(line)
+		if err != nil {
+			return nil, err
+		}
+		ops = append(ops, op)
+	}
+	return ops, scanner.Err()
+}
+
+func isSorted[T cmp.Ordered](a *Stack[T]) bool {
+	for i := 1; i < a.size(); i++ {
+		if a.at(i-1) > a.at(i) {
+			return false
+		}
+	}
+	return true
+}
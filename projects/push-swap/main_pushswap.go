@@ -1,15 +1,99 @@
 package main
 
-import "fmt"
+import (
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
 
+// Both programs share this package. Building it as "checker"
+// (go build -o checker .) runs the checker, any other name runs push-swap.
 func main() {
-	a := Stack{
-		data: []int{1, 2, 3, 4, 5, 6},
+	if filepath.Base(os.Args[0]) == "checker" {
+		checkerMain()
+		return
 	}
-	b := Stack{
-		data: []int{1, 2, 3},
+	if len(os.Args) > 1 {
+		switch os.Args[1] {
+		case "bench":
+			benchMain(os.Args[2:])
+			return
+		case "visualize":
+			visualizeMain(os.Args[2:])
+			return
+		case "gen-optimal":
+			genOptimalMain(os.Args[2:])
+			return
+		}
 	}
-	rra(&a)
-	fmt.Println(a.data)
-	fmt.Println(b.data)
+
+	fs := flag.NewFlagSet("push-swap", flag.ExitOnError)
+	strategy := fs.String("strategy", "auto", "sorting strategy, or all to print the shortest result of every strategy")
+	render := fs.String("render", "", "also write an animation of the run to this .gif or .svg file")
+	renderEvery := fs.Int("render-every", 1, "with -render, draw a frame every `n` instructions instead of after each one; raise it for large inputs to keep the file and memory use small")
+	flags, numbers := splitFlags(fs, os.Args[1:])
+	fs.Parse(flags)
+
+	nums, err := parseArgs(numbers)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "Error")
+		os.Exit(1)
+	}
+	if *render != "" {
+		if _, err := renderWriter(*render); err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+	}
+	var ops []Instruction
+	if *strategy == "all" {
+		ops, _ = solveBest(nums)
+	} else {
+		st, ok := lookupStrategy(*strategy)
+		if !ok {
+			fmt.Fprintf(os.Stderr, "unknown strategy %q\n", *strategy)
+			os.Exit(1)
+		}
+		ops = solveWith(st, nums)
+	}
+	for _, op := range ops {
+		fmt.Println(op)
+	}
+	if *render != "" {
+		if err := renderRun(*render, nums, ops, *renderEvery); err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+	}
+}
+
+// splitFlags separates leading flags defined in fs from the numbers. A
+// negative number such as -3 is not a flag, and a non-boolean flag
+// without "=" takes the next argument as its value.
+func splitFlags(fs *flag.FlagSet, args []string) ([]string, []string) {
+	i := 0
+	for i < len(args) && isFlag(args[i]) {
+		if !strings.Contains(args[i], "=") && !isBoolFlag(fs, args[i]) {
+			i++
+		}
+		i++
+	}
+	i = min(i, len(args))
+	return args[:i], args[i:]
+}
+
+func isFlag(arg string) bool {
+	name := strings.TrimLeft(arg, "-")
+	return len(name) > 0 && len(name) < len(arg) && (name[0] < '0' || name[0] > '9')
+}
+
+func isBoolFlag(fs *flag.FlagSet, arg string) bool {
+	f := fs.Lookup(strings.TrimLeft(arg, "-"))
+	if f == nil {
+		return false
+	}
+	b, ok := f.Value.(interface{ IsBoolFlag() bool })
+	return ok && b.IsBoolFlag()
 }
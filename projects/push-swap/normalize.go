@@ -0,0 +1,54 @@
+package main
+
+import (
+	"cmp"
+	"slices"
+)
+
+// Ranking maps a set of distinct values to the ranks 0..n-1 and back, so
+// strategies can work on compact ranks whatever the input values are.
+type Ranking[T cmp.Ordered] struct {
+	sorted []T
+}
+
+func newRanking[T cmp.Ordered](vals []T) Ranking[T] {
+	sorted := slices.Clone(vals)
+	slices.Sort(sorted)
+	return Ranking[T]{sorted: sorted}
+}
+
+// rank returns the position of v in sorted order, or -1 if v is unknown.
+func (r Ranking[T]) rank(v T) int {
+	i, ok := slices.BinarySearch(r.sorted, v)
+	if !ok {
+		return -1
+	}
+	return i
+}
+
+func (r Ranking[T]) value(rank int) T {
+	return r.sorted[rank]
+}
+
+// ranks replaces every value by its rank.
+func (r Ranking[T]) ranks(vals []T) []int {
+	out := make([]int, len(vals))
+	for i, v := range vals {
+		out[i] = r.rank(v)
+	}
+	return out
+}
+
+// values maps ranks back to the original values.
+func (r Ranking[T]) values(ranks []int) []T {
+	out := make([]T, len(ranks))
+	for i, k := range ranks {
+		out[i] = r.value(k)
+	}
+	return out
+}
+
+// normalize returns vals with every value replaced by its rank.
+func normalize[T cmp.Ordered](vals []T) []int {
+	return newRanking(vals).ranks(vals)
+}
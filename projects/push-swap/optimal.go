@@ -0,0 +1,188 @@
+package main
+
+//go:generate go run . gen-optimal -o optimal_table.go
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"go/format"
+	"os"
+	"strings"
+)
+
+// maxOptimal is the largest input size covered by optimalTable.
+const maxOptimal = 6
+
+// stateKey encodes a pair of stacks holding the ranks 0..n-1.
+func stateKey(a, b *Stack[int]) string {
+	key := make([]byte, 0, a.size()+b.size()+1)
+	for i := 0; i < a.size(); i++ {
+		key = append(key, byte(a.at(i)))
+	}
+	key = append(key, '|')
+	for i := 0; i < b.size(); i++ {
+		key = append(key, byte(b.at(i)))
+	}
+	return string(key)
+}
+
+func stateFromKey(key string) (*Stack[int], *Stack[int]) {
+	sa, sb, _ := strings.Cut(key, "|")
+	a, b := newStack[int](nil), newStack[int](nil)
+	for i := len(sa) - 1; i >= 0; i-- {
+		a.push(int(sa[i]))
+	}
+	for i := len(sb) - 1; i >= 0; i-- {
+		b.push(int(sb[i]))
+	}
+	return a, b
+}
+
+// distancesToSorted runs a breadth-first search backwards from the sorted
+// state of n elements over every reachable (a, b) state. Every instruction
+// that changes the state has an inverse in the instruction set, so the
+// distance from the goal is also the minimal number of instructions to
+// reach it.
+func distancesToSorted(n int) map[string]int {
+	sorted := make([]int, n)
+	for i := range sorted {
+		sorted[i] = i
+	}
+	goal := stateKey(newStack(sorted), newStack[int](nil))
+	dist := map[string]int{goal: 0}
+	queue := []string{goal}
+	for len(queue) > 0 {
+		cur := queue[0]
+		queue = queue[1:]
+		for i := range instructionNames {
+			a, b := stateFromKey(cur)
+			apply(Instruction(i), a, b)
+			next := stateKey(a, b)
+			if _, seen := dist[next]; !seen {
+				dist[next] = dist[cur] + 1
+				queue = append(queue, next)
+			}
+		}
+	}
+	return dist
+}
+
+// optimalSequence walks down dist from the state holding perm in a,
+// returning a shortest instruction sequence that sorts it.
+func optimalSequence(dist map[string]int, perm []int) []Instruction {
+	a, b := newStack(perm), newStack[int](nil)
+	var ops []Instruction
+	for d := dist[stateKey(a, b)]; d > 0; d-- {
+		for i := range instructionNames {
+			in := Instruction(i)
+			na, nb := newStack(a.values()), newStack(b.values())
+			apply(in, na, nb)
+			if dist[stateKey(na, nb)] == d-1 {
+				a, b = na, nb
+				ops = append(ops, in)
+				break
+			}
+		}
+	}
+	return ops
+}
+
+// permRank returns the lexicographic rank of a permutation of 0..n-1.
+func permRank(perm []int) int {
+	rank := 0
+	for i, v := range perm {
+		smaller := 0
+		for _, w := range perm[i+1:] {
+			if w < v {
+				smaller++
+			}
+		}
+		rank = rank*(len(perm)-i) + smaller
+	}
+	return rank
+}
+
+// permUnrank is the inverse of permRank.
+func permUnrank(n, rank int) []int {
+	digits := make([]int, n)
+	for i := n - 1; i >= 0; i-- {
+		digits[i] = rank % (n - i)
+		rank /= n - i
+	}
+	left := make([]int, n)
+	for i := range left {
+		left[i] = i
+	}
+	perm := make([]int, n)
+	for i, d := range digits {
+		perm[i] = left[d]
+		left = append(left[:d], left[d+1:]...)
+	}
+	return perm
+}
+
+// lookupOptimal returns the precomputed minimal sequence for a
+// permutation of 0..n-1, or false when it is too large for the table.
+func lookupOptimal(perm []int) ([]Instruction, bool) {
+	if len(perm) > maxOptimal || len(perm) >= len(optimalTable) {
+		return nil, false
+	}
+	var ops []Instruction
+	for _, name := range strings.Fields(optimalTable[len(perm)][permRank(perm)]) {
+		in, err := parseInstruction(name)
+		if err != nil {
+			return nil, false
+		}
+		ops = append(ops, in)
+	}
+	return ops, true
+}
+
+// genOptimalMain writes the optimal lookup table as Go source:
+//
+//	push-swap gen-optimal [-max 6] [-o optimal_table.go]
+func genOptimalMain(args []string) {
+	fs := flag.NewFlagSet("gen-optimal", flag.ExitOnError)
+	limit := fs.Int("max", maxOptimal, "largest input size to include")
+	out := fs.String("o", "optimal_table.go", "output file")
+	fs.Parse(args)
+
+	var buf bytes.Buffer
+	buf.WriteString("// Code generated by \"push-swap gen-optimal\"; DO NOT EDIT.\n\n")
+	buf.WriteString("package main\n\n")
+	buf.WriteString("// optimalTable[n][permRank(p)] is a shortest instruction sequence\n")
+	buf.WriteString("// sorting the permutation p of 0..n-1.\n")
+	buf.WriteString("var optimalTable = [...][]string{\n")
+	for n := 0; n <= *limit; n++ {
+		dist := distancesToSorted(n)
+		fmt.Fprintf(&buf, "%d: {\n", n)
+		for r := 0; r < factorial(n); r++ {
+			ops := optimalSequence(dist, permUnrank(n, r))
+			names := make([]string, len(ops))
+			for i, op := range ops {
+				names[i] = op.String()
+			}
+			fmt.Fprintf(&buf, "%q,\n", strings.Join(names, " "))
+		}
+		buf.WriteString("},\n")
+	}
+	buf.WriteString("}\n")
+
+	src, err := format.Source(buf.Bytes())
+	if err == nil {
+		err = os.WriteFile(*out, src, 0644)
+	}
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+}
+
+func factorial(n int) int {
+	f := 1
+	for i := 2; i <= n; i++ {
+		f *= i
+	}
+	return f
+}
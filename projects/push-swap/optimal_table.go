@@ -0,0 +1,896 @@
+// Code generated by "push-swap gen-optimal"; DO NOT EDIT.
+
+package main
+
+// optimalTable[n][permRank(p)] is a shortest instruction sequence
+// sorting the permutation p of 0..n-1.
+var optimalTable = [...][]string{
+	0: {
+		"",
+	},
+	1: {
+		"",
+	},
+	2: {
+		"",
+		"sa",
+	},
+	3: {
+		"",
+		"sa ra",
+		"sa",
+		"rra",
+		"ra",
+		"sa rra",
+	},
+	4: {
+		"",
+		"pb sa ra pa",
+		"pb sa pa",
+		"rra sa",
+		"sa ra",
+		"pb sa rra pa",
+		"sa",
+		"pb pb ss pa pa",
+		"pb sa pa sa",
+		"rra",
+		"sa ra sa",
+		"pb sa pa rra",
+		"sa pb sa pa",
+		"sa rra sa",
+		"sa pb sa pa sa",
+		"sa rra",
+		"ra ra",
+		"ra ra sa",
+		"ra",
+		"ra pb sa pa",
+		"ra sa",
+		"rra sa ra",
+		"sa ra ra",
+		"sa ra ra sa",
+	},
+	5: {
+		"",
+		"rra rra sa ra ra",
+		"pb pb sa pa pa",
+		"pb rra sa pa",
+		"pb sa ra pa",
+		"pb pb sa rra pa pa",
+		"pb sa pa",
+		"pb pb pb ss pa pa pa",
+		"rra rra sa ra sa",
+		"rra sa",
+		"pb sa ra sa pa",
+		"pb pb sa pa rra pa",
+		"sa rra sa ra ra",
+		"pb sa rra sa pa",
+		"pb sa pb sa pa sa pa",
+		"pb sa rra pa",
+		"pb ra ra pa",
+		"pb ra ra sa pa",
+		"sa ra",
+		"pb ra pb sa pa pa",
+		"pb ra sa pa",
+		"pb rra sa ra pa",
+		"pb sa ra ra pa",
+		"pb sa ra ra sa pa",
+		"sa",
+		"pb pb sa rr pa pa",
+		"pb pb ss pa pa",
+		"pb pb rrr pa pa",
+		"pb pb rr pa pa",
+		"pb pb sa rrr pa pa",
+		"pb sa pa sa",
+		"pb pb pb rr pa pa pa",
+		"rra rra sa ra",
+		"rra",
+		"pb rra sa pa rra",
+		"ra ra sa ra ra",
+		"pb sa pb ss pa pa",
+		"pb sa pb rrr pa pa",
+		"ra sa ra ra sa ra",
+		"pb sa pa rra",
+		"rra sa rra",
+		"rra pb rra sa pa",
+		"sa ra sa",
+		"pb ra pb ss pa pa",
+		"pb ra sa pa sa",
+		"sa rra sa ra",
+		"pb sa rra pa rra",
+		"pb pb ss pa rra pa ra",
+		"sa pb sa pa",
+		"pb pb pb rrr pa pa pa",
+		"pb pb ss pa sa pa",
+		"sa rra sa",
+		"pb pb rr pa sa pa",
+		"pb pb ss pa rra pa",
+		"sa pb sa pa sa",
+		"pb pb sa rrr pa pa rra",
+		"sa rra rra sa ra",
+		"sa rra",
+		"pb pb rrr pa pa rra",
+		"pb pb ss pa pa rra",
+		"pb rra sa pa rra rra",
+		"ra ra sa ra",
+		"pb rra sa pa rra rra sa",
+		"pb sa pa sa rra",
+		"rra rra",
+		"rra rra sa",
+		"rra sa rra rra",
+		"ra pb sa ra pa ra",
+		"rra sa rra rra sa",
+		"sa pb sa rra pa ra",
+		"ra sa ra ra",
+		"ra sa ra ra sa",
+		"rra sa ra ra",
+		"ra pb sa ra pa",
+		"rra sa rra rra sa rra",
+		"sa pb sa rra pa",
+		"ra sa ra",
+		"ra pb ra sa pa",
+		"rra sa ra ra sa",
+		"ra pb pb rr pa pa",
+		"pb ra ra pa ra sa ra",
+		"sa pb sa pa rra",
+		"sa rra sa rra",
+		"sa rra pb rra sa pa",
+		"pb pb rrr pa pa rra rra",
+		"sa ra ra sa ra",
+		"pb pb sa rrr pa pa rra rra",
+		"sa pb sa pa sa rra",
+		"sa rra rra",
+		"sa rra rra sa",
+		"ra ra",
+		"rra rra sa rra",
+		"ra ra sa",
+		"rra pb ra ra pa",
+		"pb sa pa sa rra rra",
+		"rra pb ra ra sa pa",
+		"ra",
+		"rra rra sa rra rra",
+		"ra pb sa pa",
+		"rra sa ra sa",
+		"pb sa ra pa ra",
+		"pb pb sa rra pa pa ra",
+		"ra sa",
+		"ra pb pb ss pa pa",
+		"ra pb sa pa sa",
+		"rra sa ra",
+		"pb sa ra pa ra sa",
+		"pb pb sa pa rra pa ra",
+		"sa rra sa rra rra",
+		"pb sa rra pa ra sa",
+		"sa rra sa rra rra sa",
+		"pb sa rra pa ra",
+		"pb ra ra pa ra",
+		"pb ra ra pa ra sa",
+		"sa ra ra",
+		"sa rra rra sa rra",
+		"sa ra ra sa",
+		"pb rra sa ra pa ra",
+		"pb sa ra ra pa ra",
+		"pb sa ra ra pa ra sa",
+	},
+	6: {
+		"",
+		"rra rra sa ra ra",
+		"pb pb pb sa pa pa pa",
+		"pb pb rra sa pa pa",
+		"pb pb sa ra pa pa",
+		"pb pb pb sa rra pa pa pa",
+		"pb pb sa pa pa",
+		"ra ra sa ra ra sa ra ra",
+		"pb rra rra sa ra sa pa",
+		"pb rra sa pa",
+		"pb pb sa ra sa pa pa",
+		"pb pb pb sa pa rra pa pa",
+		"pb sa rra sa ra ra pa",
+		"pb pb sa rra sa pa pa",
+		"pb pb sa pb sa pa sa pa pa",
+		"pb pb sa rra pa pa",
+		"pb pb ra ra pa pa",
+		"pb pb ra ra sa pa pa",
+		"pb sa ra pa",
+		"pb pb ra pb sa pa pa pa",
+		"pb pb ra sa pa pa",
+		"pb pb rra sa ra pa pa",
+		"pb pb sa ra ra pa pa",
+		"pb pb sa ra ra sa pa pa",
+		"pb sa pa",
+		"pb pb pb ss ra pa pa pa",
+		"pb pb pb ss pa pa pa",
+		"pb pb rra sa pa sa pa",
+		"pb pb sa ra pa sa pa",
+		"pb pb pb ss rra pa pa pa",
+		"pb pb sa pa sa pa",
+		"pb pb pb sa rra pa pa rra pa",
+		"rra rra sa ra sa",
+		"rra sa",
+		"pb pb rra sa pa rra pa",
+		"pb ra ra sa ra ra pa",
+		"pb pb sa pb ss pa pa pa",
+		"pb pb sa rra sa pa sa pa",
+		"pb ra sa ra ra sa ra pa",
+		"pb pb sa pa rra pa",
+		"pb rra sa rra pa",
+		"pb rra pb rra sa pa pa",
+		"pb sa ra sa pa",
+		"pb pb ra pb ss pa pa pa",
+		"pb pb ra sa pa sa pa",
+		"pb sa rra sa ra pa",
+		"pb pb sa rra pa rra pa",
+		"pb pb pb ss pa rra pa ra pa",
+		"pb sa pb sa pa pa",
+		"pb pb pb sa rr pa ss pa pa",
+		"pb pb pb ss pa sa pa pa",
+		"pb sa rra sa pa",
+		"pb pb pb rr pa ss pa pa",
+		"pb pb pb ss pa rra pa pa",
+		"pb sa pb sa pa sa pa",
+		"pb pb pb ss rra pa pa rra pa",
+		"pb sa rra rra sa ra pa",
+		"pb sa rra pa",
+		"pb pb rra sa pa sa rra pa",
+		"pb pb pb ss pa pa rra pa",
+		"pb pb rra sa pa rra rra pa",
+		"pb ra ra sa ra pa",
+		"pb pb rra sa pa rra rra sa pa",
+		"pb pb sa pa sa rra pa",
+		"pb rra rra pa",
+		"pb rra rra sa pa",
+		"pb rra sa rra rra pa",
+		"pb ra pb sa ra pa ra pa",
+		"pb rra sa rra rra sa pa",
+		"pb sa pb sa rra pa ra pa",
+		"pb ra sa ra ra pa",
+		"pb ra sa ra ra sa pa",
+		"sa rra sa ra ra",
+		"pb ra pb sa ra pa pa",
+		"pb rra sa rra rra sa rra pa",
+		"pb sa pb sa rra pa pa",
+		"pb ra sa ra pa",
+		"pb ra pb ra sa pa pa",
+		"pb rra sa ra ra sa pa",
+		"pb ra pb sa ra pa sa pa",
+		"pb pb ra ra pa ra sa ra pa",
+		"pb sa pb sa pa rra pa",
+		"pb sa rra sa rra pa",
+		"pb sa rra pb rra sa pa pa",
+		"pb pb rra sa pa sa rra rra pa",
+		"pb sa ra ra sa ra pa",
+		"pb pb pb ss rra pa pa rra rra pa",
+		"pb sa pb sa pa sa rra pa",
+		"pb sa rra rra pa",
+		"pb sa rra rra sa pa",
+		"pb ra ra pa",
+		"pb rra rra sa rra pa",
+		"pb ra ra sa pa",
+		"pb rra pb ra ra pa pa",
+		"pb pb sa pa sa rra rra pa",
+		"pb rra pb ra ra sa pa pa",
+		"sa ra",
+		"pb rra rra sa rra rra pa",
+		"pb ra pb sa pa pa",
+		"pb rra sa ra sa pa",
+		"pb pb sa ra pa ra pa",
+		"pb pb pb sa rra pa pa ra pa",
+		"pb ra sa pa",
+		"pb ra pb pb ss pa pa pa",
+		"pb ra pb sa pa sa pa",
+		"pb rra sa ra pa",
+		"pb pb sa ra pa ra sa pa",
+		"pb pb pb sa pa rra pa ra pa",
+		"pb sa rra sa rra rra pa",
+		"pb pb sa rra pa ra sa pa",
+		"pb sa rra sa rra rra sa pa",
+		"pb pb sa rra pa ra pa",
+		"pb pb ra ra pa ra pa",
+		"pb pb ra ra pa ra sa pa",
+		"pb sa ra ra pa",
+		"pb sa rra rra sa rra pa",
+		"pb sa ra ra sa pa",
+		"pb pb rra sa ra pa ra pa",
+		"pb pb sa ra ra pa ra pa",
+		"pb pb sa ra ra pa ra sa pa",
+		"sa",
+		"sa rra rra sa ra ra",
+		"pb pb ra sa rrr pa pa",
+		"pb pb rra ss pa pa",
+		"pb pb sa rr pa pa",
+		"pb pb pb sa pa rra ss pa pa",
+		"pb pb ss pa pa",
+		"pb pb pb sa ra pa ss pa pa",
+		"pb pb pb sa pa ss pa pa",
+		"pb pb rrr pa pa",
+		"pb pb sa ra ss pa pa",
+		"pb pb pb sa pa rrr pa pa",
+		"pb pb sa ra sa rrr pa pa",
+		"pb pb sa rra ss pa pa",
+		"pb pb sa pb sa pa ss pa pa",
+		"pb pb sa rrr pa pa",
+		"pb pb ra rr pa pa",
+		"pb pb ra ra ss pa pa",
+		"pb pb rr pa pa",
+		"pb pb ra ra sa rrr pa pa",
+		"pb pb ra ss pa pa",
+		"pb pb rra sa rr pa pa",
+		"pb pb sa ra rr pa pa",
+		"pb pb sa ra ra ss pa pa",
+		"pb sa pa sa",
+		"pb pb pb sa rr pa pa pa",
+		"pb pb ra sa pa pa rra",
+		"pb sa pb rra ss pa pa",
+		"pb pb pb rr pa pa pa",
+		"pb pb pb rr sa pa pa pa",
+		"pb pb sa pa sa pa sa",
+		"ra ra ra pb sa ra ra pa",
+		"rra rra sa ra",
+		"rra",
+		"pb pb rra sa pa pa rra",
+		"ra ra ra sa ra ra",
+		"pb pb sa ra sa pa pa rra",
+		"pb pb ra pb rr pa pa pa",
+		"ra ra sa ra ra sa ra",
+		"pb pb sa pa pa rra",
+		"pb rra sa pa rra",
+		"pb rra pb rra ss pa pa",
+		"pb sa ra sa pa sa",
+		"pb pb ra ra sa pa pa rra",
+		"pb pb sa rra sa pa pa rra",
+		"pb sa pa sa rra sa ra",
+		"pb pb sa rra pa pa rra",
+		"ra ra pb sa ra ra pa ra",
+		"pb sa pb ss pa pa",
+		"pb pb pb sa rr pa sa pa pa",
+		"pb pb pb ss pa ss pa pa",
+		"pb sa pb rrr pa pa",
+		"pb pb pb rr pa sa pa pa",
+		"pb pb pb ss pa rrr pa pa",
+		"pb pb sa ra pa sa pa rra",
+		"pb pb pb ss rra pa pa pa rra",
+		"pb sa pa rra rra sa ra",
+		"pb sa pa rra",
+		"pb pb rra sa pa sa pa rra",
+		"ra sa ra ra sa ra ra",
+		"rra pb rra sa rra pa ra",
+		"rra sa rra rra sa ra",
+		"rra rra pb rra sa ra sa pa",
+		"pb pb sa pa sa pa rra",
+		"rra sa rra",
+		"rra pb rra sa pa",
+		"pb rra sa rra pa rra",
+		"pb rra pb rra sa pa pa rra",
+		"pb rra sa rra pb rrr pa pa",
+		"pb sa pb ss pa rra pa ra",
+		"pb pb sa pa rra pa rra",
+		"pb ra sa ra ra sa pa sa",
+		"sa rra sa ra ra sa",
+		"pb ra pb sa rr pa pa",
+		"pb rra sa rra rra sa pa rra",
+		"pb sa pb sa rrr pa pa",
+		"pb ra pb rr pa pa",
+		"pb ra pb ra ss pa pa",
+		"pb rra sa ra ra sa pa sa",
+		"pb ra pb pb rr pa pa pa",
+		"sa pb rra rra pa ra sa ra",
+		"pb sa pb sa pa pa rra",
+		"pb sa rra sa pa rra",
+		"pb sa rra pb rra ss pa pa",
+		"pb pb rra sa pa sa rra pa rra",
+		"pb sa ra ra pb rr pa pa",
+		"ra pb sa ra ra pa ra sa ra",
+		"pb sa pb sa pa sa pa rra",
+		"pb sa rra pa rra",
+		"pb sa rra pb rrr pa pa",
+		"pb ra ra pa sa",
+		"pb rra rra sa pa rra",
+		"pb ra ra sa pa sa",
+		"pb rra pb ra rr pa pa",
+		"pb pb sa pa sa rra pa rra",
+		"pb rra pb ra ra ss pa pa",
+		"sa ra sa",
+		"pb rra rra sa rra pa rra",
+		"pb ra pb ss pa pa",
+		"pb rra sa ra sa pa sa",
+		"pb pb pb rr pa ra pa pa",
+		"pb pb pb sa rrr pa pa pa ra",
+		"pb ra sa pa sa",
+		"pb ra pb ra sa pa pa rra",
+		"sa rra rra pb ra ra pa",
+		"sa rra sa ra",
+		"pb pb rra ss pa rra pa ra",
+		"ra pb ra sa ra ra pa ra",
+		"pb sa rra sa rra pa rra",
+		"pb pb sa pb rrr pa pa pa ra",
+		"pb sa rra sa rra pb rrr pa pa",
+		"pb pb ss pa rra pa ra",
+		"pb pb rrr pa rra pa ra",
+		"pb rra pb rra sa rr pa pa",
+		"pb sa ra ra pa sa",
+		"pb sa rra rra sa pa rra",
+		"pb sa ra ra sa pa sa",
+		"pb sa rra pb ra rr pa pa",
+		"pb pb sa rrr pa rra pa ra",
+		"pb sa rra pb ra ra ss pa pa",
+		"sa pb sa pa",
+		"pb pb pb rrr sa pa pa pa",
+		"pb pb ra ss pa rra pa",
+		"pb pb pb rrr pa pa pa",
+		"pb pb sa rr pa sa pa",
+		"pb pb pb sa rrr pa pa pa",
+		"pb pb ss pa sa pa",
+		"pb pb sa pb rrr sa pa pa pa",
+		"sa rra rra sa ra sa",
+		"sa rra sa",
+		"pb pb rra ss pa rra pa",
+		"ra pb ra sa ra ra pa",
+		"pb pb sa ra ss pa rra pa",
+		"pb pb sa pb rrr pa pa pa",
+		"ra pb sa ra ra sa ra pa",
+		"pb pb ss pa rra pa",
+		"pb pb rrr pa rra pa",
+		"pb pb ra ra ss pa sa pa",
+		"pb pb rr pa sa pa",
+		"pb pb ra ra ss pa rra pa",
+		"pb pb ra ss pa sa pa",
+		"sa pb sa rra sa ra pa",
+		"pb pb sa rrr pa rra pa",
+		"pb pb sa ra ra ss pa sa pa",
+		"sa pb sa pa sa",
+		"pb pb pb sa rr pa pa sa pa",
+		"pb pb ra ss pa pa rra",
+		"pb pb pb sb rrr pa pa pa",
+		"pb pb pb rr pa pa sa pa",
+		"pb pb pb ss rrr pa pa pa",
+		"pb pb sa rr pa pa rra",
+		"pb pb ss pb sa rr pa pa pa",
+		"sa rra rra sa ra",
+		"sa rra",
+		"pb pb rra ss pa pa rra",
+		"sa ra ra ra sa ra ra",
+		"pb pb sa ra ss pa pa rra",
+		"pb pb rr pb rr pa pa pa",
+		"sa ra ra sa ra ra sa ra",
+		"pb pb ss pa pa rra",
+		"pb pb rrr pa pa rra",
+		"pb rra pb pb rrr pa pa pa",
+		"pb pb ra rr pa pa rra",
+		"pb pb ra ra ss pa pa rra",
+		"pb pb sa rra ss pa pa rra",
+		"pb pb rrr pb rr pa pa pa",
+		"pb pb sa rrr pa pa rra",
+		"pb pb rra pb ss rrr pa pa pa",
+		"pb sa pb ss pa sa pa",
+		"pb pb pb rr sa pa pa rra pa",
+		"ra ra pb ra sa ra pa",
+		"pb sa pa sa rra sa",
+		"pb pb sa rra pa pa rra rra",
+		"ra ra pb sa ra ra pa",
+		"pb pb pb rr pa pa pa rra",
+		"pb pb pb rr sa pa pa pa rra",
+		"pb sa pa sa rra rra sa ra",
+		"pb sa pa sa rra",
+		"pb sa pb rra ss pa pa rra",
+		"pb pb ra sa pa pa rra rra",
+		"rra pb rra rra pa ra",
+		"ra ra ra sa ra",
+		"rra pb rra rra pa ra sa",
+		"ra ra ra sa ra sa",
+		"rra rra",
+		"rra rra sa",
+		"pb rra sa pa rra rra",
+		"ra ra pb sa ra pa ra",
+		"pb rra sa pa rra rra sa",
+		"pb rra pb rra ss pa rra pa",
+		"ra ra sa ra ra",
+		"ra ra sa ra ra sa",
+		"pb rra sa rra pa rra rra",
+		"pb ra pb sa rr pa sa pa",
+		"pb rra sa rra pb rrr pa pa rra",
+		"pb sa pb ss pa rra pa",
+		"pb sa pb rrr pa rra pa",
+		"pb ra pb ra ss pa sa pa",
+		"pb rra sa rra pa rra rra sa",
+		"ra ra pb pb ra ss pa pa",
+		"pb rra sa rra pb rrr pa rra pa",
+		"pb sa pb ss pa pa rra",
+		"pb sa pb rrr pa pa rra",
+		"pb pb pb ss pa ss pa pa rra",
+		"ra sa ra ra pb sa ra pa",
+		"ra sa ra ra sa ra",
+		"ra sa ra ra pb pb rr pa pa",
+		"ra sa ra ra sa ra sa",
+		"pb sa pa rra rra",
+		"pb sa pa rra rra sa",
+		"rra sa rra rra",
+		"rra pb rra sa pa rra",
+		"rra sa rra rra sa",
+		"rra pb rra sa rra pa",
+		"ra pb sa ra pa ra ra",
+		"ra pb sa ra pa ra ra sa",
+		"pb ra ra pa sa rra",
+		"pb rra rra sa pa rra rra",
+		"pb ra pb ss pa sa pa",
+		"sa pb sa rra pa ra sa",
+		"pb sa pb sa rrr pa rra pa",
+		"pb rra pb ra ra ss pa pa rra",
+		"pb ra pb rr pa pa rra",
+		"pb ra pb ra ss pa pa rra",
+		"pb ra pb sa rr pa pa rra",
+		"sa pb sa rra pa ra",
+		"pb sa pb sa rrr pa pa rra",
+		"pb pb ra ss pa rra rra pa ra",
+		"pb sa rra sa pa rra rra",
+		"ra pb ra sa ra pa ra",
+		"pb sa rra sa pa rra rra sa",
+		"pb pb ss pa sa rra pa ra",
+		"sa pb rra rra pa ra",
+		"sa pb rra rra pa ra sa",
+		"pb sa rra pa rra rra",
+		"pb sa rra pb rrr pa pa rra",
+		"pb sa rra pa rra rra sa",
+		"pb sa rra pb rrr pa rra pa",
+		"ra pb sa ra ra pa ra",
+		"ra pb sa ra ra pa ra sa",
+		"pb pb rr pa sa rra pa",
+		"pb rra rra pa ra sa ra ra",
+		"pb pb ra ss pa sa rra pa",
+		"sa pb sa rra sa pa",
+		"pb pb pb rrr pa rra pa pa",
+		"ra pb pb sa ra ra pa pa",
+		"pb pb sa rr pa sa rra pa",
+		"pb pb pb sa rrr pa pa rra pa",
+		"sa pb sa rra rra sa ra pa",
+		"sa pb sa rra pa",
+		"pb pb pb rrr pa pa rra pa",
+		"pb pb ra ss pa rra rra pa",
+		"rra pb ra sa ra pa ra",
+		"ra pb ra sa ra pa",
+		"pb pb rra ss pa rra rra sa pa",
+		"pb pb ss pa sa rra pa",
+		"sa pb rra rra pa",
+		"sa pb rra rra sa pa",
+		"rra sa ra ra sa ra",
+		"ra pb pb sa ra pa ra pa",
+		"pb pb rrr pa rra rra sa pa",
+		"pb pb sa ra ss pa rra rra pa",
+		"ra pb sa ra ra pa",
+		"ra pb sa ra ra sa pa",
+		"pb pb rr pa sa pa rra",
+		"pb pb pb sa rrr pa rrr pa pa",
+		"pb pb ra ss pa sa pa rra",
+		"sa pb sa pb rrr pa pa",
+		"pb pb pb rrr pa rrr pa pa",
+		"ra pb pb sa ra rr pa pa",
+		"pb pb sa rr pa sa pa rra",
+		"pb pb pb sa rrr pa pa pa rra",
+		"pb ra ra pa ra ra sa ra",
+		"sa pb sa pa rra",
+		"pb pb pb rrr pa pa pa rra",
+		"pb pb ra ss pa rra pa rra",
+		"pb pb rra ss pa rra pa rra",
+		"sa rra sa rra rra sa ra",
+		"pb ra sa ra pa ra ra sa ra",
+		"pb pb ss pa sa pa rra",
+		"sa rra sa rra",
+		"sa rra pb rra sa pa",
+		"pb pb rrr pa rra pa rra",
+		"pb pb ss pb ss pa rrr pa pa",
+		"pb pb sa pb rrr pa pa pa rra",
+		"pb pb sa ra ss pa rra pa rra",
+		"pb pb ss pa rra pa rra",
+		"ra pb sa ra ra sa pa sa",
+		"pb pb ra rr pa pa rra rra",
+		"pb pb pb ss rrr pa pa rra pa",
+		"sa ra ra pb ra sa ra pa",
+		"sa pb sa pa sa rra sa",
+		"pb pb sa rrr pa pa rra rra",
+		"pb ra pb ss pa rra rra pa",
+		"pb pb pb rr pa pa sa pa rra",
+		"pb pb pb ss rrr pa pa pa rra",
+		"pb sa ra ra pa ra ra sa ra",
+		"sa pb sa pa sa rra",
+		"pb pb pb sb rrr pa pa pa rra",
+		"pb pb ra ss pa pa rra rra",
+		"sa rra pb rra rra pa ra",
+		"sa ra ra ra sa ra",
+		"sa rra pb rra rra pa ra sa",
+		"sa ra ra ra sa ra sa",
+		"sa rra rra",
+		"sa rra rra sa",
+		"pb pb rrr pa pa rra rra",
+		"sa ra ra pb sa ra pa ra",
+		"pb pb rrr pa pa rra rra sa",
+		"pb pb sa ra ss pa pa rra rra",
+		"sa ra ra sa ra ra",
+		"sa ra ra sa ra ra sa",
+		"rra pb ra ra pa ra",
+		"ra ra pb sa ra pa",
+		"pb rra sa pa rra rra sa rra",
+		"ra ra pb sa ra sa pa",
+		"ra ra sa ra",
+		"ra ra pb ra sa pa",
+		"rra pb ra ra pa ra sa",
+		"ra ra pb pb rr pa pa",
+		"pb rra sa pa rra pb rra rra pa",
+		"pb sa pb ss pa sa pa rra",
+		"ra ra sa ra sa",
+		"ra ra pb ra sa pa sa",
+		"pb sa pb rra ss pa pa rra rra",
+		"pb sa ra pa ra ra sa ra",
+		"pb pb pb rr sa pa pa pa rra rra",
+		"pb pb pb rr pa pa pa rra rra",
+		"pb sa pa sa rra rra",
+		"pb sa pa sa rra rra sa",
+		"ra ra ra",
+		"rra rra sa rra",
+		"ra ra ra sa",
+		"rra pb rra rra pa",
+		"rra rra pb rra sa pa",
+		"rra pb rra rra sa pa",
+		"rra sa ra ra ra",
+		"rra pb rra sa pa rra rra",
+		"rra sa rra rra sa rra",
+		"rra pb rra sa rra pa rra",
+		"ra pb sa ra pa ra",
+		"ra pb pb ra sa pa pa ra",
+		"rra sa ra ra ra sa",
+		"rra pb rra sa pa rra rra sa",
+		"rra sa rra pb rra rra pa",
+		"rra pb rra sa rra rra pa",
+		"ra pb sa ra pa ra sa",
+		"ra pb pb ra sa pa pa ra sa",
+		"pb sa pb rrr pa pa rra rra",
+		"pb pb ra ss pa ra ra pa ra",
+		"rra sa rra pb rra rra sa pa",
+		"rra pb rra sa rra rra sa pa",
+		"sa pb sa rra rra pa ra",
+		"sa pb sa rra rra pa ra sa",
+		"ra sa ra ra",
+		"pb sa pa rra rra sa rra",
+		"ra sa ra ra sa",
+		"rra pb ra sa ra ra pa",
+		"ra sa ra ra sa pb sa pa",
+		"rra pb ra sa ra ra sa pa",
+		"rra sa ra ra",
+		"ra pb pb sa ra pa pa",
+		"rra sa rra rra sa rra rra",
+		"sa pb sa pb sa rra pa pa",
+		"ra pb sa ra pa",
+		"ra pb pb ra sa pa pa",
+		"rra sa ra ra pb sa pa",
+		"ra pb pb sa ra pa sa pa",
+		"pb rra rra pa ra sa ra sa",
+		"pb rra sa rra pa ra ra",
+		"ra pb sa ra sa pa",
+		"ra pb pb ra sa pa sa pa",
+		"pb sa rra sa ra pa ra ra",
+		"pb pb ra ss pa ra ra pa",
+		"rra sa rra pb rra rra sa pa rra",
+		"pb pb sa ra rr pa ra ra pa",
+		"sa pb sa rra rra pa",
+		"sa pb sa rra rra sa pa",
+		"ra sa ra",
+		"sa pb rra rra sa rra pa",
+		"ra pb ra sa pa",
+		"rra pb ra pb rr pa pa",
+		"pb pb sa rr pa ra ra pa",
+		"rra pb ra pb ra ss pa pa",
+		"rra sa ra ra sa",
+		"ra pb pb sa rr pa pa",
+		"rra sa rra rra sa rra rra sa",
+		"sa pb sa pb sa rrr pa pa",
+		"ra pb pb rr pa pa",
+		"ra pb pb ra ss pa pa",
+		"rra sa ra ra pb sa pa sa",
+		"ra pb pb pb rr pa pa pa",
+		"pb rra rra pa ra sa ra",
+		"pb rra rra pa ra ra",
+		"sa pb sa rra sa pa rra",
+		"pb ra ra sa ra pa ra ra",
+		"pb pb pb rrr pa pa rra pa rra",
+		"pb pb ra ss pa ra ra pa sa",
+		"pb ra sa ra ra pa ra sa ra",
+		"pb ra sa ra ra pa ra ra",
+		"sa pb sa rra pa rra",
+		"sa pb sa rra pb rrr pa pa",
+		"ra sa ra sa",
+		"sa pb rra rra sa pa rra",
+		"ra pb ra sa pa sa",
+		"rra pb ra sa ra pa",
+		"pb pb ss pa sa rra pa rra",
+		"rra pb ra pb ra sa pa pa",
+		"pb pb rrr pa rra pa rra rra",
+		"ra pb pb sa rr pa sa pa",
+		"pb sa rra rra pa ra sa ra sa",
+		"pb sa rra sa rra pa ra ra",
+		"ra pb pb rr pa sa pa",
+		"ra pb pb ra ss pa sa pa",
+		"pb pb pb rrr pa rrr pa pa rra",
+		"sa ra ra pb pb ra ss pa pa",
+		"pb sa rra rra pa ra sa ra",
+		"pb sa rra rra pa ra ra",
+		"sa pb sa pb rrr pa pa rra",
+		"pb pb ra ss pa sa pa rra rra",
+		"pb pb pb rrr pa pa pa rra rra",
+		"pb ra ra pa ra sa ra",
+		"pb pb pb sa rrr pa pa pa rra rra",
+		"pb ra ra pa ra sa ra sa",
+		"pb ra ra pa ra ra",
+		"pb ra ra pa ra ra sa",
+		"sa rra sa rra rra",
+		"sa rra pb rra sa pa rra",
+		"sa rra sa rra rra sa",
+		"sa rra pb rra sa rra pa",
+		"pb ra sa ra pa ra ra",
+		"pb ra sa ra pa ra ra sa",
+		"pb rra sa ra pa ra ra",
+		"sa ra ra pb sa ra pa",
+		"pb pb rrr pa pa rra rra sa rra",
+		"sa ra ra pb sa ra sa pa",
+		"sa ra ra sa ra",
+		"sa ra ra pb ra sa pa",
+		"pb rra sa ra pa ra ra sa",
+		"sa ra ra pb pb rr pa pa",
+		"pb pb pb ss rrr pa pa rra pa rra",
+		"pb pb ra ra pa ra pa ra ra",
+		"sa ra ra sa ra sa",
+		"sa ra ra pb ra sa pa sa",
+		"pb pb pb sb rrr pa pa pa rra rra",
+		"pb sa ra ra pa ra sa ra",
+		"pb pb pb ss rrr pa pa pa rra rra",
+		"pb sa ra ra pa ra sa ra sa",
+		"pb sa ra ra pa ra ra",
+		"pb sa ra ra pa ra ra sa",
+		"sa ra ra ra",
+		"sa rra rra sa rra",
+		"sa ra ra ra sa",
+		"sa rra pb rra rra pa",
+		"sa rra rra pb rra sa pa",
+		"sa rra pb rra rra sa pa",
+		"ra ra",
+		"rra rra sa rra rra",
+		"ra ra pb sa pa",
+		"rra pb ra ra pa sa",
+		"rra rra pb rra sa pa rra",
+		"rra pb rra rra sa pa rra",
+		"ra ra sa",
+		"rra rra sa rra rra sa",
+		"ra ra pb sa pa sa",
+		"rra pb ra ra pa",
+		"rra rra pb rra sa rra pa",
+		"rra pb rra rra sa rra pa",
+		"ra ra sa pb sa pa",
+		"rra pb ra ra sa pa sa",
+		"ra ra sa pb sa pa sa",
+		"rra pb ra ra sa pa",
+		"pb pb ra ra pa pa ra ra",
+		"rra pb rra pb ra ra pa pa",
+		"pb sa ra pa ra ra",
+		"pb sa pa sa rra rra sa rra",
+		"pb sa ra pa ra ra sa",
+		"pb pb rra sa ra pa pa ra ra",
+		"pb pb sa ra ra pa pa ra ra",
+		"rra pb rra pb ra ra sa pa pa",
+		"ra",
+		"rra rra sa ra ra ra",
+		"ra pb pb sa pa pa",
+		"pb pb rra sa pa pa ra",
+		"pb pb sa ra pa pa ra",
+		"rra pb rra rra sa pa rra rra",
+		"ra pb sa pa",
+		"rra rra sa rra rra sa rra",
+		"ra pb pb sa pa sa pa",
+		"rra sa ra sa",
+		"pb pb sa ra sa pa pa ra",
+		"rra pb rra rra sa rra pa rra",
+		"ra pb sa pb sa pa pa",
+		"pb pb sa rra sa pa pa ra",
+		"ra pb sa pb sa pa sa pa",
+		"pb pb sa rra pa pa ra",
+		"pb pb ra ra pa pa ra",
+		"pb pb ra ra sa pa pa ra",
+		"pb sa ra pa ra",
+		"sa pb sa rra rra sa rra pa",
+		"pb pb ra sa pa pa ra",
+		"pb pb rra sa ra pa pa ra",
+		"pb pb sa ra ra pa pa ra",
+		"pb pb sa ra ra sa pa pa ra",
+		"ra sa",
+		"rra rra sa ra ra ra sa",
+		"ra pb pb ss pa pa",
+		"pb pb pb rrr pa rr pa pa",
+		"pb pb pb rr pa pa ra pa",
+		"pb pb pb sa rrr pa rr pa pa",
+		"ra pb sa pa sa",
+		"ra pb pb ra sa pa pa rra",
+		"rra rra pb ra ra pa",
+		"rra sa ra",
+		"pb pb rra sa pa rra pa ra",
+		"pb ra ra sa ra ra pa ra",
+		"ra pb sa pb ss pa pa",
+		"pb pb sa pb rrr pa rr pa pa",
+		"rra rra pb rra rra sa rra pa",
+		"pb pb sa pa rra pa ra",
+		"pb rra sa rra pa ra",
+		"rra pb rra sa ra sa pa",
+		"pb sa ra pa ra sa",
+		"sa pb sa rra rra sa pa rra",
+		"pb pb ra sa pa pa ra sa",
+		"pb sa rra sa ra pa ra",
+		"pb pb sa rra pa rra pa ra",
+		"pb pb pb ss pa rra pa ra pa ra",
+		"ra sa pb sa pa",
+		"sa pb rra rra sa pa rra rra",
+		"ra pb pb ss pa sa pa",
+		"pb sa rra pa ra sa",
+		"pb pb pb rr pa pa ra sa pa",
+		"pb pb pb ss pa rra pa pa ra",
+		"ra sa pb sa pa sa",
+		"ra pb pb ra ss pa pa rra",
+		"rra rra pb ra ra sa pa",
+		"pb sa rra pa ra",
+		"pb pb rra sa pa sa rra pa ra",
+		"pb pb pb ss pa pa rra pa ra",
+		"sa pb sa rra sa pa rra rra",
+		"pb ra ra sa ra pa ra",
+		"sa pb sa rra sa pa rra rra sa",
+		"pb pb sa pa sa rra pa ra",
+		"pb rra rra pa ra",
+		"pb rra rra pa ra sa",
+		"pb rra sa rra rra pa ra",
+		"pb ra pb sa ra pa ra pa ra",
+		"pb rra sa rra rra pa ra sa",
+		"pb sa pb sa rra pa ra pa ra",
+		"pb ra sa ra ra pa ra",
+		"pb ra sa ra ra pa ra sa",
+		"sa rra sa ra ra ra",
+		"pb ra pb sa ra pa pa ra",
+		"sa rra sa rra rra sa rra",
+		"pb sa pb sa rra pa pa ra",
+		"pb ra sa ra pa ra",
+		"pb ra pb ra sa pa pa ra",
+		"sa rra sa ra ra ra sa",
+		"pb ra pb pb rr pa pa ra pa",
+		"sa rra sa rra pb rra rra pa",
+		"pb sa pb sa pa rra pa ra",
+		"pb sa rra sa rra pa ra",
+		"pb sa rra pb rra sa pa pa ra",
+		"sa pb sa pb rrr pa pa rra rra",
+		"pb sa ra ra sa ra pa ra",
+		"sa rra sa rra pb rra rra sa pa",
+		"pb sa pb sa pa sa rra pa ra",
+		"pb sa rra rra pa ra",
+		"pb sa rra rra pa ra sa",
+		"pb ra ra pa ra",
+		"pb rra rra sa rra pa ra",
+		"pb ra ra pa ra sa",
+		"pb rra pb ra ra pa pa ra",
+		"pb pb sa pa sa rra rra pa ra",
+		"pb rra pb ra ra sa pa pa ra",
+		"sa ra ra",
+		"sa rra rra sa rra rra",
+		"sa ra ra pb sa pa",
+		"pb rra sa ra pa ra sa",
+		"pb pb sa ra pa ra pa ra",
+		"sa rra pb rra rra sa pa rra",
+		"sa ra ra sa",
+		"sa rra rra sa rra rra sa",
+		"sa ra ra pb sa pa sa",
+		"pb rra sa ra pa ra",
+		"sa rra rra pb rra sa rra pa",
+		"sa rra pb rra rra sa rra pa",
+		"sa ra ra sa pb sa pa",
+		"sa rra pb ra ra sa pa sa",
+		"sa ra ra sa pb sa pa sa",
+		"sa rra pb ra ra sa pa",
+		"pb pb ra ra pa ra pa ra",
+		"pb pb ra ra pa ra pa ra sa",
+		"pb sa ra ra pa ra",
+		"pb sa rra rra sa rra pa ra",
+		"pb sa ra ra pa ra sa",
+		"pb pb rra sa ra pa ra pa ra",
+		"pb pb sa ra ra pa ra pa ra",
+		"pb pb sa ra ra pa ra pa ra sa",
+	},
+}
@@ -0,0 +1,72 @@
+package main
+
+// Pairs of adjacent instructions that act on different stacks and can be
+// replaced by a single combined instruction.
+var merges = map[[2]Instruction]Instruction{
+	{SA, SB}: SS, {SB, SA}: SS,
+	{RA, RB}: RR, {RB, RA}: RR,
+	{RRA, RRB}: RRR, {RRB, RRA}: RRR,
+}
+
+type step struct {
+	op    Instruction
+	sizeA int // size of stack a before op runs
+}
+
+// cancels reports whether next undoes last. A push only undoes the
+// previous opposite push if that push was not a no-op on an empty stack.
+func cancels(last step, next Instruction, n int) bool {
+	if last.op.inverse() != next {
+		return false
+	}
+	switch last.op {
+	case PB:
+		return last.sizeA > 0
+	case PA:
+		return n-last.sizeA > 0
+	}
+	return true
+}
+
+// optimize removes cancelling pairs and merges mergeable pairs in ops,
+// which is assumed to start from n elements in a and an empty b. The
+// resulting sequence leaves both stacks in exactly the same final state.
+func optimize(ops []Instruction, n int) []Instruction {
+	var out []step
+	sizeA := n
+
+	var add func(s step)
+	add = func(s step) {
+		if len(out) == 0 {
+			out = append(out, s)
+			return
+		}
+		last := out[len(out)-1]
+		merged, ok := merges[[2]Instruction{last.op, s.op}]
+		switch {
+		case cancels(last, s.op, n):
+			out = out[:len(out)-1]
+		case ok:
+			out = out[:len(out)-1]
+			add(step{merged, last.sizeA})
+		default:
+			out = append(out, s)
+		}
+	}
+
+	for _, op := range ops {
+		add(step{op, sizeA})
+		switch {
+		case op == PA && sizeA < n:
+			sizeA++
+		case op == PB && sizeA > 0:
+			sizeA--
+		}
+	}
+
+	result := make([]Instruction, len(out))
+	for i, s := range out {
+		result[i] = s.op
+	}
+	return result
+}
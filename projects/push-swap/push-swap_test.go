@@ -1 +1,474 @@
 package main
+
+import (
+	"flag"
+	"math/rand"
+	"slices"
+	"strconv"
+	"strings"
+	"testing"
+	"testing/quick"
+)
+
+func TestStackRotations(t *testing.T) {
+	s := newStack([]int{1, 2, 3, 4})
+	s.rotate()
+	if got := s.values(); !slices.Equal(got, []int{2, 3, 4, 1}) {
+		t.Fatalf("rotate: got %v", got)
+	}
+	s.reverseRotate()
+	s.reverseRotate()
+	if got := s.values(); !slices.Equal(got, []int{4, 1, 2, 3}) {
+		t.Fatalf("reverseRotate: got %v", got)
+	}
+	for i := 5; i <= 20; i++ {
+		s.push(i)
+		s.rotate()
+	}
+	if s.size() != 20 || s.at(0) != 4 || s.at(19) != 20 {
+		t.Fatalf("push after rotate: got %v", s.values())
+	}
+}
+
+func replay(vals []int, ops []Instruction) ([]int, []int) {
+	a, b := newStack(vals), newStack[int](nil)
+	execute(a, b, ops)
+	return a.values(), b.values()
+}
+
+func TestParseInstruction(t *testing.T) {
+	for i, name := range instructionNames {
+		in, err := parseInstruction(name)
+		if err != nil || in != Instruction(i) || in.String() != name {
+			t.Errorf("parseInstruction(%q) = %v, %v", name, in, err)
+		}
+	}
+	for _, bad := range []string{"", "PA", "ra ", "rrrr"} {
+		if _, err := parseInstruction(bad); err == nil {
+			t.Errorf("parseInstruction(%q) succeeded", bad)
+		}
+	}
+}
+
+func TestInverseUndoes(t *testing.T) {
+	for i := range instructionNames {
+		in := Instruction(i)
+		got1, got2 := replay([]int{5, 1, 4}, []Instruction{PB, PB, in, in.inverse()})
+		want1, want2 := replay([]int{5, 1, 4}, []Instruction{PB, PB})
+		if !slices.Equal(got1, want1) || !slices.Equal(got2, want2) {
+			t.Errorf("%v followed by %v is not the identity", in, in.inverse())
+		}
+	}
+}
+
+func TestOptimizeExamples(t *testing.T) {
+	tests := []struct {
+		in   []Instruction
+		want []Instruction
+	}{
+		{[]Instruction{RA, RRA}, nil},
+		{[]Instruction{PB, PB, PA, PA}, nil},
+		{[]Instruction{SA, SB}, []Instruction{SS}},
+		{[]Instruction{RB, RA, RRA, RRB}, nil},
+		{[]Instruction{RA, PB, PA, RB}, []Instruction{RR}},
+		{[]Instruction{SA, SB, SB, SA}, nil},
+		// pa on an empty b is a no-op, so pa pb is not the identity here.
+		{[]Instruction{PA, PB}, []Instruction{PA, PB}},
+	}
+	for _, tt := range tests {
+		if got := optimize(tt.in, 4); !slices.Equal(got, tt.want) {
+			t.Errorf("optimize(%v) = %v, want %v", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestOptimizePreservesState(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	for range 2000 {
+		vals := rng.Perm(rng.Intn(8))
+		ops := make([]Instruction, rng.Intn(40))
+		for i := range ops {
+			ops[i] = Instruction(rng.Intn(len(instructionNames)))
+		}
+		opt := optimize(ops, len(vals))
+		if len(opt) > len(ops) {
+			t.Fatalf("optimize grew %v to %v", ops, opt)
+		}
+		a1, b1 := replay(vals, ops)
+		a2, b2 := replay(vals, opt)
+		if !slices.Equal(a1, a2) || !slices.Equal(b1, b2) {
+			t.Fatalf("%v on %v: %v changed final state", ops, vals, opt)
+		}
+	}
+}
+
+// sliceRotate is the previous prepend-based ra, kept as a baseline.
+func sliceRotate(data []int) []int {
+	top := data[len(data)-1]
+	return append([]int{top}, data[:len(data)-1]...)
+}
+
+func benchInput(n int) []int {
+	vals := make([]int, n)
+	for i := range vals {
+		vals[i] = i
+	}
+	return vals
+}
+
+func benchmarkRing(b *testing.B, n int) {
+	s := newStack(benchInput(n))
+	for b.Loop() {
+		for i := 0; i < n; i++ {
+			s.rotate()
+		}
+		for i := 0; i < n; i++ {
+			s.reverseRotate()
+		}
+	}
+}
+
+func benchmarkSlice(b *testing.B, n int) {
+	data := benchInput(n)
+	for b.Loop() {
+		for i := 0; i < 2*n; i++ {
+			data = sliceRotate(data)
+		}
+	}
+}
+
+func BenchmarkRotateRing500(b *testing.B)   { benchmarkRing(b, 500) }
+func BenchmarkRotateRing5000(b *testing.B)  { benchmarkRing(b, 5000) }
+func BenchmarkRotateSlice500(b *testing.B)  { benchmarkSlice(b, 500) }
+func BenchmarkRotateSlice5000(b *testing.B) { benchmarkSlice(b, 5000) }
+
+func TestBenchSize(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	for _, n := range []int{3, 5, 100} {
+		for _, r := range benchSize(rng, n, 10, strategies) {
+			if r.Invalid != 0 {
+				t.Errorf("%s size %d: %+v", r.Strategy, n, r)
+			}
+			if r.Min > r.P95 || r.P95 > r.Max {
+				t.Errorf("%s size %d: inconsistent stats %+v", r.Strategy, n, r)
+			}
+			if r.Strategy == "auto" && r.PassRate != 1 {
+				t.Errorf("auto size %d: pass rate %v", n, r.PassRate)
+			}
+		}
+	}
+}
+
+func TestPlayerBackRestoresState(t *testing.T) {
+	nums := []int{4, 1, 3, 2}
+	ops := []Instruction{PA, PB, RB, PB, SS, RRA, PA, RR}
+	p := newPlayer(nums, ops)
+	for p.forward() {
+	}
+	for p.back() {
+	}
+	if got := p.a.values(); !slices.Equal(got, nums) || !p.b.isEmpty() {
+		t.Fatalf("after rewinding: a=%v b=%v", got, p.b.values())
+	}
+}
+
+func TestPlayerRenderHighlightsLastRun(t *testing.T) {
+	p := newPlayer([]int{2, 1, 3}, []Instruction{SA, RA, RRA})
+	var sb strings.Builder
+	p.render(&sb, 10, false)
+	if strings.Contains(sb.String(), ansiReverse) {
+		t.Error("an instruction is highlighted before any has run")
+	}
+	p.forward()
+	sb.Reset()
+	p.render(&sb, 10, false)
+	if want := ansiReverse + " " + SA.String() + " "; !strings.Contains(sb.String(), want) {
+		t.Errorf("after one step, %s is not highlighted:\n%q", SA, sb.String())
+	}
+}
+
+func TestPermRank(t *testing.T) {
+	for r := range factorial(5) {
+		if got := permRank(permUnrank(5, r)); got != r {
+			t.Fatalf("permRank(permUnrank(5, %d)) = %d", r, got)
+		}
+	}
+}
+
+func TestOptimalTable(t *testing.T) {
+	for n := 0; n <= maxOptimal; n++ {
+		dist := distancesToSorted(n)
+		for r := range factorial(n) {
+			perm := permUnrank(n, r)
+			ops, ok := lookupOptimal(perm)
+			if !ok {
+				t.Fatalf("no table entry for %v", perm)
+			}
+			start := stateKey(newStack(perm), newStack[int](nil))
+			if len(ops) != dist[start] {
+				t.Fatalf("%v: table has %d instructions, optimum is %d", perm, len(ops), dist[start])
+			}
+			a, b := replay(perm, ops)
+			if !slices.IsSorted(a) || len(b) != 0 {
+				t.Fatalf("%v: %v does not sort", perm, ops)
+			}
+		}
+	}
+}
+
+func TestSplitFlags(t *testing.T) {
+	fs := flag.NewFlagSet("test", flag.ContinueOnError)
+	fs.String("strategy", "", "")
+	fs.Bool("explain", false, "")
+	flags, nums := splitFlags(fs, []string{"-strategy", "lis", "-3", "2"})
+	if !slices.Equal(flags, []string{"-strategy", "lis"}) || !slices.Equal(nums, []string{"-3", "2"}) {
+		t.Errorf("got %v %v", flags, nums)
+	}
+	flags, nums = splitFlags(fs, []string{"-strategy=all", "3 -2 1"})
+	if !slices.Equal(flags, []string{"-strategy=all"}) || !slices.Equal(nums, []string{"3 -2 1"}) {
+		t.Errorf("got %v %v", flags, nums)
+	}
+	flags, nums = splitFlags(fs, []string{"-explain", "3", "1"})
+	if !slices.Equal(flags, []string{"-explain"}) || !slices.Equal(nums, []string{"3", "1"}) {
+		t.Errorf("got %v %v", flags, nums)
+	}
+}
+
+func TestSolveBest(t *testing.T) {
+	nums := rand.New(rand.NewSource(2)).Perm(60)
+	best, winner := solveBest(nums)
+	if winner == nil || !sortsInput(nums, best) {
+		t.Fatalf("solveBest did not sort %v", nums)
+	}
+	for _, st := range strategies {
+		if ops := solveWith(st, nums); len(ops) < len(best) {
+			t.Errorf("%s found %d instructions, best was %d", st.Name(), len(ops), len(best))
+		}
+	}
+}
+
+func TestRanking(t *testing.T) {
+	vals := []int{-7, 42, 0, -1, 3}
+	r := newRanking(vals)
+	ranks := r.ranks(vals)
+	if !slices.Equal(ranks, []int{0, 4, 2, 1, 3}) {
+		t.Fatalf("ranks = %v", ranks)
+	}
+	if back := r.values(ranks); !slices.Equal(back, vals) {
+		t.Fatalf("values = %v", back)
+	}
+	if r.rank(5) != -1 {
+		t.Errorf("rank of unknown value = %d", r.rank(5))
+	}
+}
+
+func TestSolveStrings(t *testing.T) {
+	words := []string{"pear", "apple", "fig", "kiwi", "banana", "cherry", "date", "grape"}
+	for _, st := range strategies {
+		ops := solveWith(st, words)
+		a, b := newStack(words), newStack[string](nil)
+		execute(a, b, ops)
+		if !isSorted(a) || !b.isEmpty() {
+			t.Errorf("%s: got a=%v b=%v", st.Name(), a.values(), b.values())
+		}
+	}
+}
+
+// splitState builds a state from vals with the first split%(len+1)
+// values in a and the rest in b.
+func splitState(vals []int, split uint8) (*Stack[int], *Stack[int]) {
+	k := int(split) % (len(vals) + 1)
+	return newStack(slices.Clone(vals[:k])), newStack(slices.Clone(vals[k:]))
+}
+
+func multiset(a, b *Stack[int]) []int {
+	all := append(a.values(), b.values()...)
+	slices.Sort(all)
+	return all
+}
+
+func TestPropertyPreservesElements(t *testing.T) {
+	prop := func(vals []int, split, op uint8) bool {
+		a, b := splitState(vals, split)
+		before := multiset(a, b)
+		apply(Instruction(int(op)%len(instructionNames)), a, b)
+		return slices.Equal(before, multiset(a, b))
+	}
+	if err := quick.Check(prop, nil); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestPropertyInverseIsIdentity(t *testing.T) {
+	prop := func(vals []int, split, op uint8) bool {
+		in := Instruction(int(op) % len(instructionNames))
+		a, b := splitState(vals, split)
+		if in == PA && b.isEmpty() || in == PB && a.isEmpty() {
+			return true // a push from an empty stack cannot be undone
+		}
+		wantA, wantB := a.values(), b.values()
+		apply(in, a, b)
+		apply(in.inverse(), a, b)
+		return slices.Equal(a.values(), wantA) && slices.Equal(b.values(), wantB)
+	}
+	if err := quick.Check(prop, nil); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestPropertyCombinedInstructions(t *testing.T) {
+	pairs := map[Instruction][2]Instruction{
+		SS:  {SA, SB},
+		RR:  {RA, RB},
+		RRR: {RRA, RRB},
+	}
+	prop := func(vals []int, split uint8) bool {
+		for combined, parts := range pairs {
+			a1, b1 := splitState(vals, split)
+			a2, b2 := splitState(vals, split)
+			apply(combined, a1, b1)
+			execute(a2, b2, parts[:])
+			if !slices.Equal(a1.values(), a2.values()) || !slices.Equal(b1.values(), b2.values()) {
+				return false
+			}
+		}
+		return true
+	}
+	if err := quick.Check(prop, nil); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestPropertySolverSorts(t *testing.T) {
+	prop := func(vals []int16) bool {
+		seen := map[int16]bool{}
+		var nums []int
+		for _, v := range vals {
+			if !seen[v] {
+				seen[v] = true
+				nums = append(nums, int(v))
+			}
+		}
+		return sortsInput(nums, solve(nums))
+	}
+	if err := quick.Check(prop, &quick.Config{MaxCount: 200}); err != nil {
+		t.Error(err)
+	}
+}
+
+func fuzzOps(data []byte) []Instruction {
+	ops := make([]Instruction, len(data))
+	for i, d := range data {
+		ops[i] = Instruction(int(d) % len(instructionNames))
+	}
+	return ops
+}
+
+func FuzzInstructions(f *testing.F) {
+	f.Add(uint8(5), []byte{0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10})
+	f.Add(uint8(0), []byte{1, 1, 9, 0})
+	want := []int{0, 1, 2, 3, 4, 5, 6, 7, 8, 9}
+	f.Fuzz(func(t *testing.T, split uint8, data []byte) {
+		a, b := splitState(want, split)
+		for i, in := range fuzzOps(data) {
+			apply(in, a, b)
+			if got := multiset(a, b); !slices.Equal(got, want) {
+				t.Fatalf("after %d instructions: elements are %v", i+1, got)
+			}
+		}
+	})
+}
+
+func FuzzOptimize(f *testing.F) {
+	f.Add(uint8(4), []byte{0, 1, 5, 8, 2, 3})
+	f.Fuzz(func(t *testing.T, n uint8, data []byte) {
+		vals := rand.New(rand.NewSource(int64(n))).Perm(int(n) % 12)
+		ops := fuzzOps(data)
+		a1, b1 := replay(vals, ops)
+		a2, b2 := replay(vals, optimize(ops, len(vals)))
+		if !slices.Equal(a1, a2) || !slices.Equal(b1, b2) {
+			t.Fatalf("optimize changed the final state of %v on %v", ops, vals)
+		}
+	})
+}
+
+func FuzzSolve(f *testing.F) {
+	f.Add(int64(1), uint8(5))
+	f.Add(int64(7), uint8(100))
+	f.Fuzz(func(t *testing.T, seed int64, n uint8) {
+		rng := rand.New(rand.NewSource(seed))
+		nums := rng.Perm(int(n))
+		for i := range nums {
+			nums[i] -= int(n) / 2
+		}
+		if ops := solve(nums); !sortsInput(nums, ops) {
+			t.Fatalf("solver output does not sort %v", nums)
+		}
+	})
+}
+
+func FuzzParseArgs(f *testing.F) {
+	for _, s := range []string{"3 2 1 0", "0 one 2 3", "1 2 2 3", "", "-5 +7", "9223372036854775808"} {
+		f.Add(s)
+	}
+	f.Fuzz(func(t *testing.T, s string) {
+		nums, err := parseArgs([]string{s})
+		if err != nil {
+			return
+		}
+		seen := map[int]bool{}
+		parts := make([]string, len(nums))
+		for i, n := range nums {
+			if seen[n] {
+				t.Fatalf("parseArgs(%q) accepted duplicate %d", s, n)
+			}
+			seen[n] = true
+			parts[i] = strconv.Itoa(n)
+		}
+		again, err := parseArgs(parts)
+		if err != nil || !slices.Equal(again, nums) {
+			t.Fatalf("parseArgs(%q) = %v, reparsed as %v, %v", s, nums, again, err)
+		}
+		if len(nums) != len(strings.Fields(s)) {
+			t.Fatalf("parseArgs(%q) returned %d numbers", s, len(nums))
+		}
+	})
+}
+
+func TestRenderFrames(t *testing.T) {
+	nums := rand.New(rand.NewSource(3)).Perm(200)
+	ops := solve(nums)
+	frames := renderFrames(nums, ops, 1)
+	if len(frames) != len(ops)+1 {
+		t.Errorf("%d frames for %d instructions", len(frames), len(ops))
+	}
+	if sampled := renderFrames(nums, ops, 10); len(sampled) != (len(ops)+9)/10+1 || sampled[1].step != 10 {
+		t.Errorf("every 10: %d frames for %d instructions", len(sampled), len(ops))
+	}
+	last := frames[len(frames)-1]
+	if last.step != len(ops) || !slices.IsSorted(last.a) || len(last.b) != 0 {
+		t.Errorf("last frame is step %d of %d: a sorted %v, b %v", last.step, len(ops), slices.IsSorted(last.a), last.b)
+	}
+	var buf strings.Builder
+	if err := writeSVG(&buf, frames[:3], len(nums)); err != nil || !strings.Contains(buf.String(), "<animate") {
+		t.Errorf("writeSVG: %v", err)
+	}
+}
+
+func TestDiagnose(t *testing.T) {
+	nums := []int{3, 2, 1, 0}
+	d := diagnose(nums, []Instruction{RRA, PB, SA, RRA, PA})
+	if !d.ok || d.firstNoop != 0 || d.misplaced != 0 || d.longestRun != 4 {
+		t.Errorf("sorting sequence: %+v", d)
+	}
+	d = diagnose(nums, []Instruction{PA, SA, RRA, PB})
+	if d.ok || d.firstNoop != 1 || d.noop != PA || !slices.Equal(d.finalB, []int{0}) {
+		t.Errorf("no-op sequence: %+v", d)
+	}
+	d = diagnose(nums, []Instruction{RRA, PB, SA, RRA})
+	var out strings.Builder
+	d.write(&out)
+	if !strings.HasPrefix(out.String(), "KO: unfinished") {
+		t.Errorf("unfinished sequence reported as %q", out.String())
+	}
+}
@@ -0,0 +1,225 @@
+package main
+
+import (
+	"encoding/xml"
+	"fmt"
+	"image"
+	"image/color"
+	"image/gif"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+const (
+	renderPanel  = 240 // width of each stack panel in pixels
+	renderGap    = 8
+	renderHeight = 480
+	renderDelay  = 4 // hundredths of a second per GIF frame
+	renderColors = 64
+)
+
+// frame is a snapshot of both stacks, as ranks, after an instruction.
+type frame struct {
+	a, b []int
+	step int
+}
+
+// renderFrames replays ops on nums with the instruction executor and
+// returns the initial state and the state after every instruction, or
+// after every few instructions when every is more than 1. The final state
+// is always included.
+func renderFrames(nums []int, ops []Instruction, every int) []frame {
+	a, b := newStack(normalize(nums)), newStack[int](nil)
+	frames := []frame{{a.values(), b.values(), 0}}
+	for i, op := range ops {
+		apply(op, a, b)
+		if (i+1)%max(every, 1) == 0 || i == len(ops)-1 {
+			frames = append(frames, frame{a.values(), b.values(), i + 1})
+		}
+	}
+	return frames
+}
+
+// barColor returns a blue to red gradient position for rank out of n.
+func barColor(rank, n int) color.RGBA {
+	t := 0
+	if n > 1 {
+		t = rank * 255 / (n - 1)
+	}
+	return color.RGBA{uint8(t), 64, uint8(255 - t), 255}
+}
+
+type barRect struct {
+	x, y, w, h int
+	rank       int
+}
+
+// layoutBars positions one horizontal bar per element, top of each stack
+// first, a on the left and b on the right.
+func layoutBars(f frame, n int) []barRect {
+	rowH := max(renderHeight/max(n, 1), 1)
+	var bars []barRect
+	for col, vals := range [][]int{f.a, f.b} {
+		x := col * (renderPanel + renderGap)
+		for i, r := range vals {
+			w := (r + 1) * renderPanel / max(n, 1)
+			bars = append(bars, barRect{x, i * rowH, max(w, 1), rowH, r})
+		}
+	}
+	return bars
+}
+
+func renderSize(n int) (int, int) {
+	return 2*renderPanel + renderGap, max(renderHeight/max(n, 1), 1) * max(n, 1)
+}
+
+func writeGIF(w io.Writer, frames []frame, n int) error {
+	palette := color.Palette{color.RGBA{24, 24, 24, 255}}
+	for i := range renderColors {
+		palette = append(palette, barColor(i, renderColors))
+	}
+	width, height := renderSize(n)
+	anim := &gif.GIF{}
+	for _, f := range frames {
+		img := image.NewPaletted(image.Rect(0, 0, width, height), palette)
+		for _, bar := range layoutBars(f, n) {
+			idx := uint8(1)
+			if n > 1 {
+				idx += uint8(bar.rank * (renderColors - 1) / (n - 1))
+			}
+			for y := bar.y; y < bar.y+bar.h; y++ {
+				for x := bar.x; x < bar.x+bar.w; x++ {
+					img.SetColorIndex(x, y, idx)
+				}
+			}
+		}
+		anim.Image = append(anim.Image, img)
+		anim.Delay = append(anim.Delay, renderDelay)
+	}
+	anim.Delay[len(anim.Delay)-1] = 200
+	return gif.EncodeAll(w, anim)
+}
+
+type svgDoc struct {
+	XMLName xml.Name   `xml:"svg"`
+	Xmlns   string     `xml:"xmlns,attr"`
+	Width   int        `xml:"width,attr"`
+	Height  int        `xml:"height,attr"`
+	Bg      svgRect    `xml:"rect"`
+	Frames  []svgFrame `xml:"g"`
+}
+
+type svgFrame struct {
+	Visibility string     `xml:"visibility,attr"`
+	Rects      []svgRect  `xml:"rect"`
+	Animate    svgAnimate `xml:"animate"`
+}
+
+type svgRect struct {
+	X      int    `xml:"x,attr"`
+	Y      int    `xml:"y,attr"`
+	Width  int    `xml:"width,attr"`
+	Height int    `xml:"height,attr"`
+	Fill   string `xml:"fill,attr"`
+}
+
+type svgAnimate struct {
+	AttributeName string `xml:"attributeName,attr"`
+	Values        string `xml:"values,attr"`
+	KeyTimes      string `xml:"keyTimes,attr"`
+	Dur           string `xml:"dur,attr"`
+	CalcMode      string `xml:"calcMode,attr"`
+	RepeatCount   string `xml:"repeatCount,attr"`
+}
+
+// writeSVG draws every frame as a group that an SMIL animation makes
+// visible for its slice of the timeline. Viewers without SMIL show the
+// final frame.
+func writeSVG(w io.Writer, frames []frame, n int) error {
+	width, height := renderSize(n)
+	doc := svgDoc{
+		Xmlns:  "http://www.w3.org/2000/svg",
+		Width:  width,
+		Height: height,
+		Bg:     svgRect{Width: width, Height: height, Fill: "#181818"},
+	}
+	total := len(frames)
+	dur := strconv.FormatFloat(float64(total*renderDelay)/100+2, 'f', 2, 64) + "s"
+	for i, f := range frames {
+		var values, times []string
+		if i > 0 {
+			values, times = append(values, "hidden"), append(times, "0")
+		}
+		values = append(values, "visible")
+		times = append(times, keyTime(i, total))
+		if i < total-1 {
+			values = append(values, "hidden")
+			times = append(times, keyTime(i+1, total))
+		}
+		vis := "hidden"
+		if i == total-1 {
+			vis = "visible"
+		}
+		g := svgFrame{
+			Visibility: vis,
+			Animate: svgAnimate{
+				AttributeName: "visibility",
+				Values:        strings.Join(values, ";"),
+				KeyTimes:      strings.Join(times, ";"),
+				Dur:           dur,
+				CalcMode:      "discrete",
+				RepeatCount:   "indefinite",
+			},
+		}
+		for _, bar := range layoutBars(f, n) {
+			c := barColor(bar.rank, n)
+			g.Rects = append(g.Rects, svgRect{bar.x, bar.y, bar.w, bar.h, fmt.Sprintf("#%02x%02x%02x", c.R, c.G, c.B)})
+		}
+		doc.Frames = append(doc.Frames, g)
+	}
+	io.WriteString(w, xml.Header)
+	enc := xml.NewEncoder(w)
+	if err := enc.Encode(doc); err != nil {
+		return err
+	}
+	return enc.Close()
+}
+
+// keyTime spreads the frames over the first part of the timeline and
+// keeps the last frame up for the final two seconds.
+func keyTime(i, total int) string {
+	animated := float64(total*renderDelay) / 100
+	return strconv.FormatFloat(animated*float64(i)/float64(total)/(animated+2), 'f', 4, 64)
+}
+
+// renderWriter picks the encoder from the extension of path.
+func renderWriter(path string) (func(io.Writer, []frame, int) error, error) {
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".gif":
+		return writeGIF, nil
+	case ".svg":
+		return writeSVG, nil
+	}
+	return nil, fmt.Errorf("unsupported render format %q, use .gif or .svg", filepath.Ext(path))
+}
+
+// renderRun writes an animation of ops sorting nums to path, with a frame
+// every every instructions.
+func renderRun(path string, nums []int, ops []Instruction, every int) error {
+	write, err := renderWriter(path)
+	if err != nil {
+		return err
+	}
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	if err := write(f, renderFrames(nums, ops, every), len(nums)); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
@@ -1 +1,377 @@
 package main
+
+import (
+	"cmp"
+	"slices"
+)
+
+// sorter runs instructions on a and b, which hold ranks, and records them.
+type sorter struct {
+	a, b *Stack[int]
+	ops  []Instruction
+}
+
+func (s *sorter) do(ins ...Instruction) {
+	for _, in := range ins {
+		apply(in, s.a, s.b)
+		s.ops = append(s.ops, in)
+	}
+}
+
+func (s *sorter) repeat(in Instruction, n int) {
+	for range n {
+		s.do(in)
+	}
+}
+
+// Strategy is one way of sorting stack a. Sort is only called with at
+// least four unsorted ranks 0..n-1 in a and an empty b; it must leave a
+// sorted and b empty.
+type Strategy interface {
+	Name() string
+	Sort(s *sorter)
+}
+
+type strategyFunc struct {
+	name string
+	sort func(s *sorter)
+}
+
+func (f strategyFunc) Name() string   { return f.name }
+func (f strategyFunc) Sort(s *sorter) { f.sort(s) }
+
+// strategies is the registry used by -strategy, in the order they are
+// reported.
+var strategies []Strategy
+
+func registerStrategy(st Strategy) {
+	strategies = append(strategies, st)
+}
+
+func init() {
+	registerStrategy(strategyFunc{"auto", sortAuto})
+	registerStrategy(strategyFunc{"greedy", sortLarge})
+	registerStrategy(strategyFunc{"lis", sortLIS})
+	registerStrategy(strategyFunc{"chunk", sortChunks})
+	registerStrategy(strategyFunc{"radix", sortRadix})
+}
+
+func lookupStrategy(name string) (Strategy, bool) {
+	for _, st := range strategies {
+		if st.Name() == name {
+			return st, true
+		}
+	}
+	return nil, false
+}
+
+// solve returns an instruction sequence that sorts vals, top first.
+func solve[T cmp.Ordered](vals []T) []Instruction {
+	st, _ := lookupStrategy("auto")
+	return solveWith(st, vals)
+}
+
+// solveWith normalizes vals to ranks and sorts them with st.
+func solveWith[T cmp.Ordered](st Strategy, vals []T) []Instruction {
+	nums := normalize(vals)
+	s := &sorter{a: newStack(nums), b: newStack[int](nil)}
+	switch {
+	case alreadySorted(s.a):
+		return nil
+	case len(nums) == 2:
+		s.do(SA)
+	case len(nums) == 3:
+		sort3(s)
+	default:
+		st.Sort(s)
+	}
+	return optimize(s.ops, len(nums))
+}
+
+// solveBest runs every registered strategy on nums and returns the
+// shortest sequence that the checker accepts, with the winning strategy.
+func solveBest[T cmp.Ordered](nums []T) ([]Instruction, Strategy) {
+	var best []Instruction
+	var winner Strategy
+	for _, st := range strategies {
+		ops := solveWith(st, nums)
+		if !sortsInput(nums, ops) {
+			continue
+		}
+		if winner == nil || len(ops) < len(best) {
+			best, winner = ops, st
+		}
+	}
+	return best, winner
+}
+
+// sortsInput reports whether ops leaves nums sorted in a with b empty.
+func sortsInput[T cmp.Ordered](nums []T, ops []Instruction) bool {
+	a, b := newStack(nums), newStack[T](nil)
+	execute(a, b, ops)
+	return isSorted(a) && b.isEmpty()
+}
+
+// sortAuto uses the precomputed optimal sequences for small inputs and
+// the greedy insertion sort above that.
+func sortAuto(s *sorter) {
+	if ops, ok := lookupOptimal(s.a.values()); ok {
+		s.do(ops...)
+		return
+	}
+	if s.a.size() <= 5 {
+		sort5(s)
+		return
+	}
+	sortLarge(s)
+}
+
+func alreadySorted(a *Stack[int]) bool {
+	return isSorted(a)
+}
+
+// sort3 sorts the three elements of a in at most two instructions.
+func sort3(s *sorter) {
+	a := s.a
+	switch {
+	case a.at(0) > a.at(1) && a.at(0) > a.at(2):
+		s.do(RA)
+	case a.at(1) > a.at(0) && a.at(1) > a.at(2):
+		s.do(RRA)
+	}
+	if a.at(0) > a.at(1) {
+		s.do(SA)
+	}
+}
+
+// sort5 pushes the smallest elements to b, sorts the last three and
+// pushes them back.
+func sort5(s *sorter) {
+	for s.a.size() > 3 {
+		rotateTo(s, findMinIndex(s.a), true)
+		s.do(PB)
+	}
+	sort3(s)
+	s.repeat(PA, s.b.size())
+}
+
+func findMinIndex(st *Stack[int]) int {
+	best := 0
+	for i := 1; i < st.size(); i++ {
+		if st.at(i) < st.at(best) {
+			best = i
+		}
+	}
+	return best
+}
+
+// rotateTo brings the element at index to the top of a (or b) using the
+// cheaper rotation direction.
+func rotateTo(s *sorter, index int, isA bool) {
+	st, up, down := s.b, RB, RRB
+	if isA {
+		st, up, down = s.a, RA, RRA
+	}
+	if index <= st.size()/2 {
+		s.repeat(up, index)
+	} else {
+		s.repeat(down, st.size()-index)
+	}
+}
+
+// sortLarge pushes everything but three elements to b, keeping the
+// smaller half towards the bottom of b, then inserts the elements back
+// into a one at a time, always picking the one that is cheapest to place.
+func sortLarge(s *sorter) {
+	n := s.a.size()
+	for s.a.size() > 3 {
+		s.do(PB)
+		if top, _ := s.b.peek(); top < n/2 {
+			s.do(RB)
+		}
+	}
+	sort3(s)
+	insertBack(s)
+}
+
+// insertBack empties b into a, which must already be circularly sorted,
+// and finally rotates the minimum of a to the top.
+func insertBack(s *sorter) {
+	for !s.b.isEmpty() {
+		m := cheapestMove(s.a, s.b)
+		m.run(s)
+		s.do(PA)
+	}
+	rotateTo(s, findMinIndex(s.a), true)
+}
+
+// sortLIS keeps the longest circularly increasing subsequence of a in
+// place, pushes everything else to b and inserts it back greedily.
+func sortLIS(s *sorter) {
+	n := s.a.size()
+	keep := longestIncreasing(s.a)
+	for range n {
+		top, _ := s.a.peek()
+		if keep[top] {
+			s.do(RA)
+			continue
+		}
+		s.do(PB)
+		if top < n/2 {
+			s.do(RB)
+		}
+	}
+	insertBack(s)
+}
+
+// longestIncreasing returns the values of the longest increasing
+// subsequence of a, trying every rotation of a as the starting point.
+func longestIncreasing(a *Stack[int]) map[int]bool {
+	n := a.size()
+	var best []int
+	for start := range n {
+		// tails[k] is the index of the smallest tail of an increasing
+		// run of length k+1; prev links each index to its predecessor.
+		var tails []int
+		prev := make([]int, n)
+		at := func(i int) int { return a.at((start + i) % n) }
+		for i := range n {
+			k, _ := slices.BinarySearchFunc(tails, at(i), func(t, v int) int { return at(t) - v })
+			if k > 0 {
+				prev[i] = tails[k-1]
+			} else {
+				prev[i] = -1
+			}
+			if k == len(tails) {
+				tails = append(tails, i)
+			} else {
+				tails[k] = i
+			}
+		}
+		if len(tails) <= len(best) {
+			continue
+		}
+		best = best[:0]
+		for i := tails[len(tails)-1]; i >= 0; i = prev[i] {
+			best = append(best, a.at((start+i)%n))
+		}
+	}
+	keep := make(map[int]bool, len(best))
+	for _, v := range best {
+		keep[v] = true
+	}
+	return keep
+}
+
+// sortChunks pushes a to b one rank range at a time, so that b ends up
+// roughly sorted in descending order, then pulls the maximum of b back
+// until b is empty.
+func sortChunks(s *sorter) {
+	n := s.a.size()
+	chunk := 15
+	if n > 150 {
+		chunk = 35
+	}
+	for pushed := 0; !s.a.isEmpty(); {
+		top, _ := s.a.peek()
+		switch {
+		case top <= pushed:
+			s.do(PB, RB)
+			pushed++
+		case top <= pushed+chunk:
+			s.do(PB)
+			pushed++
+		default:
+			s.do(RA)
+		}
+	}
+	for !s.b.isEmpty() {
+		best := 0
+		for i := 1; i < s.b.size(); i++ {
+			if s.b.at(i) > s.b.at(best) {
+				best = i
+			}
+		}
+		rotateTo(s, best, false)
+		s.do(PA)
+	}
+}
+
+// sortRadix sorts the ranks of a bit by bit, least significant first.
+func sortRadix(s *sorter) {
+	n := s.a.size()
+	for bit := 0; (n-1)>>bit > 0; bit++ {
+		for range n {
+			top, _ := s.a.peek()
+			if top>>bit&1 == 0 {
+				s.do(PB)
+			} else {
+				s.do(RA)
+			}
+		}
+		s.repeat(PA, s.b.size())
+	}
+}
+
+// move is the set of rotations that brings an element of b to the top of
+// b and its insertion point in a to the top of a.
+type move struct {
+	ra, rb, rra, rrb int
+}
+
+func (m move) cost() int {
+	return max(m.ra, m.rb) + max(m.rra, m.rrb)
+}
+
+func (m move) run(s *sorter) {
+	both := min(m.ra, m.rb)
+	s.repeat(RR, both)
+	s.repeat(RA, m.ra-both)
+	s.repeat(RB, m.rb-both)
+	both = min(m.rra, m.rrb)
+	s.repeat(RRR, both)
+	s.repeat(RRA, m.rra-both)
+	s.repeat(RRB, m.rrb-both)
+}
+
+// insertIndex returns the index in a that v must be rotated to so that a
+// stays circularly sorted after pushing v on top.
+func insertIndex(a *Stack[int], v int) int {
+	best := -1
+	for i := 0; i < a.size(); i++ {
+		if x := a.at(i); x > v && (best < 0 || x < a.at(best)) {
+			best = i
+		}
+	}
+	if best < 0 {
+		return findMinIndex(a)
+	}
+	return best
+}
+
+func cheapestMove(a, b *Stack[int]) move {
+	var best move
+	bestCost := -1
+	for j := 0; j < b.size(); j++ {
+		i := insertIndex(a, b.at(j))
+		upA, downA := i, a.size()-i
+		upB, downB := j, b.size()-j
+		if i == 0 {
+			downA = 0
+		}
+		if j == 0 {
+			downB = 0
+		}
+		for _, m := range []move{
+			{ra: upA, rb: upB},
+			{rra: downA, rrb: downB},
+			{ra: upA, rrb: downB},
+			{rra: downA, rb: upB},
+		} {
+			if c := m.cost(); bestCost < 0 || c < bestCost {
+				best, bestCost = m, c
+			}
+		}
+	}
+	return best
+}
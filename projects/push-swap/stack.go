@@ -1,30 +1,108 @@
 package main
 
-type Stack struct {
-	data []int
+import "cmp"
+
+// Stack is a double-ended ring buffer. Index 0 of the logical view is the
+// top of the stack, so push/pop and both rotations are O(1). The solver
+// only uses Stack[int] holding ranks, but any ordered type works.
+type Stack[T cmp.Ordered] struct {
+	buf  []T
+	head int
+	n    int
+}
+
+// newStack builds a stack whose top is vals[0].
+func newStack[T cmp.Ordered](vals []T) *Stack[T] {
+	s := &Stack[T]{buf: make([]T, len(vals))}
+	copy(s.buf, vals)
+	s.n = len(vals)
+	return s
+}
+
+func (s *Stack[T]) idx(i int) int {
+	return (s.head + i) % len(s.buf)
+}
+
+func (s *Stack[T]) grow() {
+	size := 2 * len(s.buf)
+	if size == 0 {
+		size = 8
+	}
+	buf := make([]T, size)
+	for i := 0; i < s.n; i++ {
+		buf[i] = s.buf[s.idx(i)]
+	}
+	s.buf = buf
+	s.head = 0
 }
 
-func (s *Stack) push(val int) {
-	s.data = append(s.data, val)
+func (s *Stack[T]) push(val T) {
+	if s.n == len(s.buf) {
+		s.grow()
+	}
+	s.head = (s.head - 1 + len(s.buf)) % len(s.buf)
+	s.buf[s.head] = val
+	s.n++
 }
-func (s *Stack) pop() (int, bool) {
+func (s *Stack[T]) pop() (T, bool) {
 	if s.isEmpty() {
-		return 0, false
+		var zero T
+		return zero, false
 	}
-	index := len(s.data) - 1
-	topItem := s.data[index]
-	s.data = s.data[:index]
+	topItem := s.buf[s.head]
+	s.head = s.idx(1)
+	s.n--
 	return topItem, true
 }
-func (s *Stack) peek() (int, bool) {
+func (s *Stack[T]) peek() (T, bool) {
 	if s.isEmpty() {
-		return 0, false
+		var zero T
+		return zero, false
+	}
+	return s.buf[s.head], true
+}
+func (s *Stack[T]) isEmpty() bool {
+	return s.n == 0
+}
+func (s *Stack[T]) size() int {
+	return s.n
+}
+
+// at returns the i-th element counting from the top.
+func (s *Stack[T]) at(i int) T {
+	return s.buf[s.idx(i)]
+}
+
+// swap exchanges the two top elements.
+func (s *Stack[T]) swap() {
+	if s.n >= 2 {
+		i, j := s.head, s.idx(1)
+		s.buf[i], s.buf[j] = s.buf[j], s.buf[i]
+	}
+}
+
+// rotate moves the top element to the bottom.
+func (s *Stack[T]) rotate() {
+	if s.n > 1 {
+		s.buf[s.idx(s.n)] = s.buf[s.head]
+		s.head = s.idx(1)
 	}
-	return s.data[len(s.data)-1], true
 }
-func (s *Stack) isEmpty() bool {
-	return len(s.data) == 0
+
+// reverseRotate moves the bottom element to the top.
+func (s *Stack[T]) reverseRotate() {
+	if s.n > 1 {
+		bottom := s.buf[s.idx(s.n-1)]
+		s.head = (s.head - 1 + len(s.buf)) % len(s.buf)
+		s.buf[s.head] = bottom
+	}
 }
-func (s *Stack) size() int {
-	return len(s.data)
+
+// values returns the elements from top to bottom.
+func (s *Stack[T]) values() []T {
+	out := make([]T, s.n)
+	for i := range out {
+		out[i] = s.at(i)
+	}
+	return out
 }
@@ -1 +1,26 @@
 package main
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+)
+
+var errInvalidArgs = errors.New("Error")
+
+// parseArgs accepts the numbers either as separate arguments or as one
+// space separated argument. The first number ends up on top of stack a.
+func parseArgs(args []string) ([]int, error) {
+	fields := strings.Fields(strings.Join(args, " "))
+	nums := make([]int, 0, len(fields))
+	seen := make(map[int]bool, len(fields))
+	for _, f := range fields {
+		n, err := strconv.Atoi(f)
+		if err != nil || seen[n] {
+			return nil, errInvalidArgs
+		}
+		seen[n] = true
+		nums = append(nums, n)
+	}
+	return nums, nil
+}
@@ -0,0 +1,243 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"strings"
+	"time"
+)
+
+const (
+	ansiClear   = "\x1b[H\x1b[2J"
+	ansiReset   = "\x1b[0m"
+	ansiReverse = "\x1b[7m"
+	ansiBarA    = "\x1b[42m"
+	ansiBarB    = "\x1b[44m"
+	ansiHideCur = "\x1b[?25l"
+	ansiShowCur = "\x1b[?25h"
+)
+
+// player steps an instruction sequence forwards and backwards over a
+// pair of stacks.
+type player struct {
+	a, b  *Stack[int]
+	ops   []Instruction
+	noop  []bool // noop[i] is set when ops[i] had no effect when applied
+	pos   int    // number of instructions applied so far
+	rank  Ranking[int]
+	delay time.Duration
+}
+
+func newPlayer(nums []int, ops []Instruction) *player {
+	return &player{
+		a:     newStack(nums),
+		b:     newStack[int](nil),
+		ops:   ops,
+		noop:  make([]bool, len(ops)),
+		rank:  newRanking(nums),
+		delay: 200 * time.Millisecond,
+	}
+}
+
+func (p *player) forward() bool {
+	if p.pos >= len(p.ops) {
+		return false
+	}
+	op := p.ops[p.pos]
+	p.noop[p.pos] = op == PA && p.b.isEmpty() || op == PB && p.a.isEmpty()
+	apply(op, p.a, p.b)
+	p.pos++
+	return true
+}
+
+// back undoes the last instruction with its inverse. Pushes that did not
+// move anything are simply skipped.
+func (p *player) back() bool {
+	if p.pos == 0 {
+		return false
+	}
+	p.pos--
+	if !p.noop[p.pos] {
+		apply(p.ops[p.pos].inverse(), p.a, p.b)
+	}
+	return true
+}
+
+// render draws both stacks as bars, top first, with the instruction
+// strip underneath and the current instruction highlighted.
+func (p *player) render(w io.Writer, rows int, playing bool) {
+	var sb strings.Builder
+	sb.WriteString(ansiClear)
+	n := len(p.rank.sorted)
+	width := 30
+	bar := func(s *Stack[int], i int, color string) string {
+		if i >= s.size() {
+			return strings.Repeat(" ", width+8)
+		}
+		v := s.at(i)
+		l := 1
+		if n > 1 {
+			l = 1 + p.rank.rank(v)*(width-1)/(n-1)
+		}
+		return fmt.Sprintf("%6d %s%s%s%s ", v, color, strings.Repeat(" ", l), ansiReset, strings.Repeat(" ", width-l))
+	}
+	fmt.Fprintf(&sb, "%-*s%s\n", width+8, "a", "b")
+	height := min(max(p.a.size(), p.b.size()), rows)
+	for i := range height {
+		sb.WriteString(bar(p.a, i, ansiBarA))
+		sb.WriteString(bar(p.b, i, ansiBarB))
+		sb.WriteByte('\n')
+	}
+	if hidden := max(p.a.size(), p.b.size()) - height; hidden > 0 {
+		fmt.Fprintf(&sb, "... %d more rows\n", hidden)
+	}
+	sb.WriteByte('\n')
+	// Highlight the instruction that was just run.
+	last := p.pos - 1
+	from := max(last-6, 0)
+	for i := from; i < min(from+14, len(p.ops)); i++ {
+		if i == last {
+			fmt.Fprintf(&sb, "%s %s %s ", ansiReverse, p.ops[i], ansiReset)
+		} else {
+			fmt.Fprintf(&sb, " %s  ", p.ops[i])
+		}
+	}
+	state := "paused"
+	if playing {
+		state = "playing"
+	}
+	fmt.Fprintf(&sb, "\n\nstep %d/%d  %s  delay %v\n", p.pos, len(p.ops), state, p.delay)
+	sb.WriteString("space play/pause  n/→ step  p/← back  +/- speed  q quit\n")
+	io.WriteString(w, sb.String())
+}
+
+// visualizeMain shows an instruction stream being run on the given
+// numbers:
+//
+//	push-swap "3 2 1" | push-swap visualize "3 2 1"
+//	push-swap visualize -ops moves.txt "3 2 1"
+func visualizeMain(args []string) {
+	fs := flag.NewFlagSet("visualize", flag.ExitOnError)
+	opsFile := fs.String("ops", "", "file with one instruction per line (default stdin)")
+	rows := fs.Int("rows", 40, "maximum number of stack rows to draw")
+	flags, numbers := splitFlags(fs, args)
+	fs.Parse(flags)
+
+	nums, err := parseArgs(numbers)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "Error")
+		os.Exit(1)
+	}
+	in := os.Stdin
+	if *opsFile != "" {
+		if in, err = os.Open(*opsFile); err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+	}
+	ops, err := readInstructions(in)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "Error")
+		os.Exit(1)
+	}
+
+	tty, err := os.Open("/dev/tty")
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "visualize needs a terminal:", err)
+		os.Exit(1)
+	}
+	defer tty.Close()
+	restore := rawMode(tty)
+	defer restore()
+
+	p := newPlayer(nums, ops)
+	p.run(os.Stdout, readKeys(tty), *rows)
+}
+
+// rawMode switches the terminal to unbuffered input without echo and
+// returns a function restoring the previous settings.
+func rawMode(tty *os.File) func() {
+	cmd := exec.Command("stty", "-g")
+	cmd.Stdin = tty
+	saved, err := cmd.Output()
+	set := exec.Command("stty", "cbreak", "-echo")
+	set.Stdin = tty
+	set.Run()
+	fmt.Print(ansiHideCur)
+	return func() {
+		fmt.Print(ansiShowCur)
+		if err == nil {
+			reset := exec.Command("stty", strings.TrimSpace(string(saved)))
+			reset.Stdin = tty
+			reset.Run()
+		}
+	}
+}
+
+// readKeys turns terminal input into key names. Arrow keys arrive as
+// escape sequences and are reported as "left" and "right".
+func readKeys(r io.Reader) <-chan string {
+	keys := make(chan string)
+	go func() {
+		defer close(keys)
+		buf := make([]byte, 8)
+		for {
+			n, err := r.Read(buf)
+			if err != nil {
+				return
+			}
+			switch s := string(buf[:n]); s {
+			case "\x1b[C":
+				keys <- "right"
+			case "\x1b[D":
+				keys <- "left"
+			default:
+				for _, c := range s {
+					keys <- string(c)
+				}
+			}
+		}
+	}()
+	return keys
+}
+
+func (p *player) run(w io.Writer, keys <-chan string, rows int) {
+	playing := false
+	timer := time.NewTimer(p.delay)
+	defer timer.Stop()
+	p.render(w, rows, playing)
+	for {
+		select {
+		case k, ok := <-keys:
+			if !ok {
+				return
+			}
+			switch k {
+			case "q":
+				return
+			case " ":
+				playing = !playing
+				timer.Reset(p.delay)
+			case "n", "right":
+				playing = false
+				p.forward()
+			case "p", "left":
+				playing = false
+				p.back()
+			case "+":
+				p.delay = max(p.delay/2, 10*time.Millisecond)
+			case "-":
+				p.delay = min(p.delay*2, 5*time.Second)
+			}
+		case <-timer.C:
+			if playing && !p.forward() {
+				playing = false
+			}
+			timer.Reset(p.delay)
+		}
+		p.render(w, rows, playing)
+	}
+}